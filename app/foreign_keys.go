@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// cascadeForeignKeys adalah semua tabel yang barisnya milik baris induk dan
+// harus ikut terhapus bersama induknya, mis. saat project di-purge dari trash.
+// Tabel baru yang punya kolom project_id (atau induk lain) didaftarkan di sini.
+var cascadeForeignKeys = []struct {
+	Table, Column, Parent string
+}{
+	{"tasks", "project_id", "projects"},
+	{"task_status_histories", "project_id", "projects"},
+	{"project_snapshots", "project_id", "projects"},
+	{"project_members", "project_id", "projects"},
+	{"project_share_links", "project_id", "projects"},
+	{"webhooks", "project_id", "projects"},
+	{"webhook_deliveries", "webhook_id", "webhooks"},
+	{"email_digest_items", "project_id", "projects"},
+	{"notifications", "project_id", "projects"},
+}
+
+// migrateCascadeForeignKeys memasang foreign key ON DELETE CASCADE yang belum ada.
+// Baris yatim (induknya sudah terhapus) dibersihkan dulu agar constraint bisa dipasang.
+func migrateCascadeForeignKeys(db *gorm.DB) {
+	for _, key := range cascadeForeignKeys {
+		name := fmt.Sprintf("fk_%s_%s", key.Table, key.Column)
+		execMigration(db, fmt.Sprintf(`DO $$ BEGIN
+			IF NOT EXISTS (SELECT FROM pg_constraint WHERE conname = '%[1]s') THEN
+				DELETE FROM %[2]s c WHERE c.%[3]s IS NOT NULL AND NOT EXISTS (SELECT 1 FROM %[4]s p WHERE p.id = c.%[3]s);
+				ALTER TABLE %[2]s ADD CONSTRAINT %[1]s FOREIGN KEY (%[3]s) REFERENCES %[4]s(id) ON DELETE CASCADE;
+			END IF;
+		END $$`, name, key.Table, key.Column, key.Parent))
+	}
+}
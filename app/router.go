@@ -7,6 +7,7 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	"task-management/controller"
+	"task-management/exception"
 	"task-management/middleware"
 )
 
@@ -25,8 +26,19 @@ func WrapHandlerWithJWT(handler httprouter.Handle) httprouter.Handle {
 	}
 }
 
-func NewRouter(userController controller.UserController, profileController controller.ProfileController, projectController controller.ProjectController, taskController controller.TaskController) *httprouter.Router {
+// WrapStreamHandlerWithJWT seperti WrapHandlerWithJWT, dengan token boleh lewat query access_token
+func WrapStreamHandlerWithJWT(handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r, ps)
+		})
+		middleware.JWTAuthWithQueryToken(h).ServeHTTP(w, r)
+	}
+}
+
+func NewRouter(userController controller.UserController, profileController controller.ProfileController, projectController controller.ProjectController, taskController controller.TaskController, projectTemplateController controller.ProjectTemplateController, projectSnapshotController controller.ProjectSnapshotController, projectMemberController controller.ProjectMemberController, projectBurndownController controller.ProjectBurndownController, trashController controller.TrashController, projectHealthController controller.ProjectHealthController, workspaceController controller.WorkspaceController, projectShareController controller.ProjectShareController, webhookController controller.WebhookController, projectEventController controller.ProjectEventController, collaborationController controller.CollaborationController, notificationController controller.NotificationController, calendarFeedController controller.CalendarFeedController, projectReportController controller.ProjectReportController) *httprouter.Router {
 	router := httprouter.New()
+	router.PanicHandler = exception.ErrorHandler
 
 	// Auth & user
 	router.POST("/api/users", userController.Register)
@@ -44,6 +56,11 @@ func NewRouter(userController controller.UserController, profileController contr
 	router.PUT("/api/users/:userId", WrapHandlerWithJWT(userController.Update))   // update user
 	router.DELETE("/api/users/:userId", WrapHandlerWithJWT(userController.Delete)) // delete user
 
+	// Workspace (tenant) user yang sedang login
+	router.GET("/api/workspace", WrapHandlerWithJWT(workspaceController.FindCurrent))
+	router.PUT("/api/workspace", WrapHandlerWithJWT(workspaceController.UpdateCurrent))
+	router.POST("/api/workspace/users", WrapHandlerWithJWT(userController.Register)) // tambah user ke workspace sendiri
+
 	// Profile routes
 	router.POST("/api/profiles", WrapHandlerWithJWT(profileController.Create))
 	router.GET("/api/profiles", WrapHandlerWithJWT(profileController.FindAll))
@@ -51,6 +68,23 @@ func NewRouter(userController controller.UserController, profileController contr
 	router.GET("/api/profiles/by-id/:profileId", WrapHandlerWithJWT(profileController.FindById))
 	router.PUT("/api/profiles/by-id/:profileId", WrapHandlerWithJWT(profileController.Update))
 	router.DELETE("/api/profiles/by-id/:profileId", WrapHandlerWithJWT(profileController.Delete))
+	router.PUT("/api/profiles/by-user/:userId/avatar", WrapHandlerWithJWT(profileController.UploadAvatar))
+	router.DELETE("/api/profiles/by-user/:userId/avatar", WrapHandlerWithJWT(profileController.DeleteAvatar))
+
+	// Inbox notifikasi dan preference email user yang sedang login
+	router.GET("/api/me/watching", WrapHandlerWithJWT(taskController.FindWatching))
+	router.GET("/api/me/notifications", WrapHandlerWithJWT(notificationController.FindAll))
+	router.POST("/api/me/notifications/read", WrapHandlerWithJWT(notificationController.MarkRead))
+	router.POST("/api/me/notifications/unread", WrapHandlerWithJWT(notificationController.MarkUnread))
+	router.POST("/api/me/notifications/read-all", WrapHandlerWithJWT(notificationController.MarkAllRead))
+	router.GET("/api/me/calendar-feeds", WrapHandlerWithJWT(calendarFeedController.FindAll))
+	router.POST("/api/me/calendar-feeds", WrapHandlerWithJWT(calendarFeedController.Create))
+	router.DELETE("/api/me/calendar-feeds/:id", WrapHandlerWithJWT(calendarFeedController.Revoke))
+	router.GET("/api/me/notification-preferences", WrapHandlerWithJWT(notificationController.FindPreference))
+	router.PUT("/api/me/notification-preferences", WrapHandlerWithJWT(notificationController.UpdatePreference))
+
+	// Avatar publik (tanpa login) dengan header cache
+	router.GET("/avatars/:userId/:size", profileController.Avatar)
 
 	// Projects API
 	router.POST("/api/projects", WrapHandlerWithJWT(projectController.Create))
@@ -59,6 +93,56 @@ func NewRouter(userController controller.UserController, profileController contr
 	router.GET("/api/projects/by-id/:id", WrapHandlerWithJWT(projectController.FindById))
 	router.PUT("/api/projects/by-id/:id", WrapHandlerWithJWT(projectController.Update))
 	router.DELETE("/api/projects/by-id/:id", WrapHandlerWithJWT(projectController.Delete))
+	router.POST("/api/projects/by-id/:id/clone", WrapHandlerWithJWT(projectController.Clone))
+	router.PUT("/api/projects/by-id/:id/status", WrapHandlerWithJWT(projectController.ChangeStatus))
+	router.POST("/api/projects/by-id/:id/archive", WrapHandlerWithJWT(projectController.Archive))
+	router.POST("/api/projects/by-id/:id/unarchive", WrapHandlerWithJWT(projectController.Unarchive))
+	router.GET("/api/projects/by-id/:id/snapshots", WrapHandlerWithJWT(projectSnapshotController.FindByProjectId))
+	router.GET("/api/projects/by-id/:id/burndown", WrapHandlerWithJWT(projectBurndownController.Burndown))
+	router.GET("/api/projects/by-id/:id/health", WrapHandlerWithJWT(projectHealthController.Health))
+	router.GET("/api/projects/by-id/:id/report.pdf", WrapHandlerWithJWT(projectReportController.Report))
+
+	// Project members API
+	router.GET("/api/projects/by-id/:id/members", WrapHandlerWithJWT(projectMemberController.FindByProjectId))
+	router.POST("/api/projects/by-id/:id/members", WrapHandlerWithJWT(projectMemberController.Create))
+	router.PUT("/api/projects/by-id/:id/members/:userId", WrapHandlerWithJWT(projectMemberController.Update))
+	router.DELETE("/api/projects/by-id/:id/members/:userId", WrapHandlerWithJWT(projectMemberController.Delete))
+
+	// Stream perubahan task project (Server-Sent Events)
+	router.GET("/api/projects/by-id/:id/events", WrapStreamHandlerWithJWT(projectEventController.Stream))
+
+	// Kolaborasi board lewat WebSocket (presence dan edit optimistis)
+	router.GET("/api/collaboration/ws", WrapStreamHandlerWithJWT(collaborationController.Connect))
+
+	// Import task dari CSV
+	router.POST("/api/projects/by-id/:id/import", WrapHandlerWithJWT(taskController.Import))
+
+	// Project share links API
+	router.GET("/api/projects/by-id/:id/share-links", WrapHandlerWithJWT(projectShareController.FindByProjectId))
+	router.POST("/api/projects/by-id/:id/share-links", WrapHandlerWithJWT(projectShareController.Create))
+	router.DELETE("/api/projects/by-id/:id/share-links/:linkId", WrapHandlerWithJWT(projectShareController.Revoke))
+
+	// Public (tanpa login), akses lewat token share link
+	router.GET("/public/share/:token", projectShareController.FindShared)
+	router.GET("/public/unsubscribe/:token", notificationController.UnsubscribePage)
+	router.POST("/public/unsubscribe/:token", notificationController.Unsubscribe)
+	router.GET("/public/calendar/:token", calendarFeedController.Export)
+
+	// Webhooks API
+	router.GET("/api/webhooks", WrapHandlerWithJWT(webhookController.FindAll))
+	router.POST("/api/webhooks", WrapHandlerWithJWT(webhookController.Create))
+	router.GET("/api/webhooks/:id", WrapHandlerWithJWT(webhookController.FindById))
+	router.PUT("/api/webhooks/:id", WrapHandlerWithJWT(webhookController.Update))
+	router.DELETE("/api/webhooks/:id", WrapHandlerWithJWT(webhookController.Delete))
+	router.GET("/api/webhooks/:id/deliveries", WrapHandlerWithJWT(webhookController.FindDeliveries))
+	router.POST("/api/webhooks/:id/deliveries/:deliveryId/redeliver", WrapHandlerWithJWT(webhookController.Redeliver))
+
+	// Project templates API
+	router.POST("/api/projects/by-id/:id/template", WrapHandlerWithJWT(projectTemplateController.Create))
+	router.POST("/api/projects/from-template/:templateId", WrapHandlerWithJWT(projectTemplateController.Instantiate))
+	router.GET("/api/templates", WrapHandlerWithJWT(projectTemplateController.FindAll))
+	router.GET("/api/templates/:templateId", WrapHandlerWithJWT(projectTemplateController.FindById))
+	router.DELETE("/api/templates/:templateId", WrapHandlerWithJWT(projectTemplateController.Delete))
 
 	// Tasks API
 	router.POST("/api/tasks", WrapHandlerWithJWT(taskController.Create))
@@ -67,7 +151,16 @@ func NewRouter(userController controller.UserController, profileController contr
 	router.GET("/api/tasks/id/:id", WrapHandlerWithJWT(taskController.FindById))
 	router.PUT("/api/tasks/:id", WrapHandlerWithJWT(taskController.Update))
 	router.DELETE("/api/tasks/id/:id", WrapHandlerWithJWT(taskController.Delete))
+	router.POST("/api/tasks/id/:id/watch", WrapHandlerWithJWT(taskController.Watch))
+	router.DELETE("/api/tasks/id/:id/watch", WrapHandlerWithJWT(taskController.Unwatch))
 	router.GET("/api/tasks/project/:projectId", WrapHandlerWithJWT(taskController.FindByProjectId))
+	router.GET("/api/tasks/project/:projectId/export.csv", WrapHandlerWithJWT(taskController.ExportCsv))
+	router.POST("/api/tasks/bulk", WrapHandlerWithJWT(taskController.Bulk))
+
+	// Trash API
+	router.GET("/api/trash", WrapHandlerWithJWT(trashController.FindAll))
+	router.POST("/api/trash/projects/:id/restore", WrapHandlerWithJWT(trashController.RestoreProject))
+	router.POST("/api/trash/tasks/:id/restore", WrapHandlerWithJWT(trashController.RestoreTask))
 
 	// swagger docs
 	router.GET("/swagger/*any", WrapHandlerWithHttprouter(middleware.CORS(httpSwagger.Handler(
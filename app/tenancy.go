@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+// tenantPolicy mengizinkan baris milik workspace di setting app.workspace_id,
+// atau semua baris jika transaksi ditandai app.bypass_rls (job sistem).
+const tenantPolicy = `current_setting('app.bypass_rls', true) = 'on'
+	OR %s = NULLIF(current_setting('app.workspace_id', true), '')::uuid`
+
+// tenantTables punya kolom workspace sendiri; childTenantTables mengikuti
+// tabel induknya (EXISTS pada tabel induk ikut terfilter policy induk).
+var (
+	tenantTables = map[string]string{
+		"workspaces":        "id",
+		"users":             "workspace_id",
+		"projects":          "workspace_id",
+		"project_templates": "workspace_id",
+		"webhooks":          "workspace_id",
+	}
+	childTenantTables = map[string]string{
+		"tasks":                    "EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id)",
+		"project_members":          "EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id)",
+		"project_snapshots":        "EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id)",
+		"task_status_histories":    "EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id)",
+		"project_share_links":      "EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id)",
+		"project_template_tasks":   "EXISTS (SELECT 1 FROM project_templates t WHERE t.id = template_id)",
+		"profiles":                 "EXISTS (SELECT 1 FROM users u WHERE u.id = user_id)",
+		"webhook_deliveries":       "EXISTS (SELECT 1 FROM webhooks w WHERE w.id = webhook_id)",
+		"notification_preferences": "EXISTS (SELECT 1 FROM users u WHERE u.id = user_id)",
+		"email_messages":           "EXISTS (SELECT 1 FROM users u WHERE u.id = user_id)",
+		"email_digest_items":       "EXISTS (SELECT 1 FROM users u WHERE u.id = user_id)",
+		"notifications":            "EXISTS (SELECT 1 FROM users u WHERE u.id = user_id)",
+		"task_watchers":            "EXISTS (SELECT 1 FROM tasks t WHERE t.id = task_id)",
+		"calendar_feeds":           "EXISTS (SELECT 1 FROM users u WHERE u.id = user_id)",
+	}
+)
+
+// migrateWorkspaces membuat tabel workspaces, memindahkan data lama ke
+// workspace default, lalu memasang policy row-level security.
+func migrateWorkspaces(db *gorm.DB) {
+	migrator := db.Migrator()
+	createTableIfNotExists(migrator, &domain.Workspace{}, "workspaces")
+	addColumnIfNotExists(migrator, &domain.User{}, "users", "workspace_id")
+	addColumnIfNotExists(migrator, &domain.Project{}, "projects", "workspace_id")
+	addColumnIfNotExists(migrator, &domain.ProjectTemplate{}, "project_templates", "workspace_id")
+
+	execMigration(db, `INSERT INTO workspaces(id, name) VALUES(?, 'Default') ON CONFLICT (id) DO NOTHING`, domain.DefaultWorkspaceId)
+	for _, table := range []string{"users", "projects", "project_templates"} {
+		execMigration(db, "UPDATE "+table+" SET workspace_id = ? WHERE workspace_id IS NULL", domain.DefaultWorkspaceId)
+	}
+
+	// Role tanpa BYPASSRLS yang dipakai helper.BeginTx lewat SET LOCAL ROLE
+	execMigration(db, `DO $$ BEGIN
+		IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = '`+helper.TenantRole+`') THEN
+			CREATE ROLE `+helper.TenantRole+` NOLOGIN;
+		END IF;
+	END $$`)
+	execMigration(db, "GRANT "+helper.TenantRole+" TO CURRENT_USER")
+	execMigration(db, "GRANT USAGE ON SCHEMA public TO "+helper.TenantRole)
+	execMigration(db, "GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO "+helper.TenantRole)
+	// Kolom serial (mis. outbox_events.sequence) butuh nextval pada sequence-nya
+	execMigration(db, "GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO "+helper.TenantRole)
+	// Tabel dan sequence yang dibuat migrasi berikutnya langsung bisa dipakai role tenant
+	execMigration(db, "ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO "+helper.TenantRole)
+	execMigration(db, "ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO "+helper.TenantRole)
+
+	for table, column := range tenantTables {
+		enableTenantPolicy(db, table, fmt.Sprintf(tenantPolicy, column))
+	}
+	for table, condition := range childTenantTables {
+		enableTenantPolicy(db, table, "current_setting('app.bypass_rls', true) = 'on' OR "+condition)
+	}
+}
+
+func enableTenantPolicy(db *gorm.DB, table string, condition string) {
+	execMigration(db, "ALTER TABLE "+table+" ENABLE ROW LEVEL SECURITY")
+	execMigration(db, "DROP POLICY IF EXISTS tenant_isolation ON "+table)
+	execMigration(db, "CREATE POLICY tenant_isolation ON "+table+" USING ("+condition+") WITH CHECK ("+condition+")")
+}
+
+// execMigration menjalankan satu perintah migrasi. Kegagalan dicatat tanpa
+// menghentikan startup, dan dikembalikan untuk pemanggil yang perlu tahu hasilnya.
+func execMigration(db *gorm.DB, SQL string, args ...interface{}) error {
+	err := db.Exec(SQL, args...).Error
+	if err != nil {
+		fmt.Printf("⚠️ Migration failed: %v\n", err)
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"task-management/helper"
+)
+
+// migrateTimestampsToUTC mengubah semua kolom "timestamp without time zone"
+// (users, refresh_tokens, ...) menjadi timestamptz. Nilai lama ditulis dengan
+// waktu lokal server, jadi ditafsirkan di LEGACY_TIMEZONE, atau zona lokal
+// proses (TZ) jika bernama. Konversi tidak bisa dibatalkan, sehingga migrasi
+// ditunda selama zona tersebut tidak diketahui dengan pasti.
+// Kolom yang sudah timestamptz dilewati, sehingga aman dijalankan berulang.
+func migrateTimestampsToUTC(db *gorm.DB) {
+	var columns []struct {
+		TableName  string
+		ColumnName string
+	}
+	err := db.Raw(`SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = 'public' AND data_type = 'timestamp without time zone'`).Scan(&columns).Error
+	if err != nil {
+		fmt.Printf("⚠️ Failed to list timestamp columns: %v\n", err)
+		return
+	}
+	if len(columns) == 0 {
+		return
+	}
+
+	legacyTimezone := legacyTimezone()
+	if legacyTimezone == "" {
+		fmt.Println("⚠️ Timestamp migration skipped: set LEGACY_TIMEZONE to the zone the server used to write existing timestamps")
+		return
+	}
+	if _, err := time.LoadLocation(legacyTimezone); err != nil {
+		fmt.Printf("⚠️ Invalid LEGACY_TIMEZONE %q, skipping timestamp migration\n", legacyTimezone)
+		return
+	}
+
+	// DDL tidak menerima parameter; nama zona sudah divalidasi di atas
+	zone := "'" + strings.ReplaceAll(legacyTimezone, "'", "''") + "'"
+	for _, column := range columns {
+		err := execMigration(db, fmt.Sprintf(`ALTER TABLE %q ALTER COLUMN %q TYPE timestamptz USING %q AT TIME ZONE %s`,
+			column.TableName, column.ColumnName, column.ColumnName, zone))
+		if err == nil {
+			fmt.Printf("✅ Converted %s.%s to timestamptz (as %s)\n", column.TableName, column.ColumnName, legacyTimezone)
+		}
+	}
+}
+
+// legacyTimezone mengembalikan LEGACY_TIMEZONE, atau nama zona lokal proses
+// (mis. dari TZ). Zona dari /etc/localtime tidak punya nama IANA ("Local"),
+// sehingga dianggap tidak diketahui.
+func legacyTimezone() string {
+	if timezone := helper.GetEnv("LEGACY_TIMEZONE", ""); timezone != "" {
+		return timezone
+	}
+	if name := time.Local.String(); name != "Local" {
+		return name
+	}
+	return ""
+}
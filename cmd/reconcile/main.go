@@ -0,0 +1,37 @@
+// Command reconcile mencari profile yang tidak sinkron dengan user-nya
+// (hilang, atau FullName/Email/Role berbeda) lalu memperbaikinya.
+//
+//	go run ./cmd/reconcile            # perbaiki data
+//	go run ./cmd/reconcile -dry-run   # hanya laporkan
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"task-management/app"
+	"task-management/helper"
+	"task-management/repository"
+	"task-management/service"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report drifted profiles without fixing them")
+	flag.Parse()
+
+	db := app.NewDB()
+	defer db.Close()
+
+	profileSyncService := service.NewProfileSyncService(
+		repository.NewUserRepository(db),
+		repository.NewProfileRepository(db),
+		db,
+	)
+	result := profileSyncService.Reconcile(context.Background(), *dryRun)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	helper.PanicIfError(encoder.Encode(result))
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type CalendarFeedController interface {
+	Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Revoke(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Export(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// CalendarFeedControllerImpl adalah implementasi dari CalendarFeedController
+type CalendarFeedControllerImpl struct {
+	CalendarFeedService service.CalendarFeedService
+}
+
+// NewCalendarFeedController membuat instance CalendarFeedController baru
+func NewCalendarFeedController(calendarFeedService service.CalendarFeedService) CalendarFeedController {
+	return &CalendarFeedControllerImpl{
+		CalendarFeedService: calendarFeedService,
+	}
+}
+
+// @Summary Create calendar feed
+// @Description Create a personal, token-authenticated iCalendar (.ics) feed of task due dates and project milestones
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Param feed body web.CalendarFeedCreateRequest false "Feed options"
+// @Success 200 {object} web.CalendarFeedResponse
+// @Security BearerAuth
+// @Router /me/calendar-feeds [post]
+func (controller *CalendarFeedControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	feedCreateRequest := web.CalendarFeedCreateRequest{}
+	if request.ContentLength != 0 {
+		helper.ReadFromRequestBody(request, &feedCreateRequest)
+	}
+
+	feedResponse := controller.CalendarFeedService.Create(request.Context(), feedCreateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   feedResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary List calendar feeds
+// @Description Get all calendar feeds of the current user, including revoked ones
+// @Tags calendar
+// @Produce json
+// @Success 200 {array} web.CalendarFeedResponse
+// @Security BearerAuth
+// @Router /me/calendar-feeds [get]
+func (controller *CalendarFeedControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	feedResponses := controller.CalendarFeedService.FindAll(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   feedResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Revoke calendar feed
+// @Description Revoke a calendar feed so its URL stops working immediately
+// @Tags calendar
+// @Produce json
+// @Param id path string true "Calendar feed ID"
+// @Success 200 {object} web.CalendarFeedResponse
+// @Security BearerAuth
+// @Router /me/calendar-feeds/{id} [delete]
+func (controller *CalendarFeedControllerImpl) Revoke(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	feedId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	feedResponse := controller.CalendarFeedService.Revoke(request.Context(), feedId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   feedResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Calendar feed
+// @Description iCalendar (RFC 5545) feed for calendar apps, authenticated by the feed token (no login required)
+// @Tags public
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token, optionally with .ics suffix"
+// @Success 200 {string} string "iCalendar document"
+// @Router /public/calendar/{token} [get]
+func (controller *CalendarFeedControllerImpl) Export(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	// Sebagian klien kalender hanya mengenali URL yang berakhiran .ics
+	token := strings.TrimSuffix(params.ByName("token"), ".ics")
+	calendar := controller.CalendarFeedService.Export(request.Context(), token)
+
+	writer.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	writer.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	writer.Header().Set("Cache-Control", "private, no-cache")
+	_, err := calendar.WriteTo(writer)
+	helper.PanicIfError(err)
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/net/websocket"
+	"task-management/event"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+const (
+	// collaborationHeartbeatInterval menjaga koneksi tetap hidup melewati proxy
+	collaborationHeartbeatInterval = 30 * time.Second
+	// collaborationWriteTimeout memutus client yang tidak membaca data
+	collaborationWriteTimeout = 10 * time.Second
+	// collaborationMaxMessageBytes membatasi ukuran pesan dari client
+	collaborationMaxMessageBytes = 64 << 10
+)
+
+// CollaborationControllerImpl adalah implementasi dari CollaborationController
+type CollaborationControllerImpl struct {
+	CollaborationService service.CollaborationService
+}
+
+// NewCollaborationController membuat instance CollaborationController baru
+func NewCollaborationController(collaborationService service.CollaborationService) CollaborationController {
+	return &CollaborationControllerImpl{
+		CollaborationService: collaborationService,
+	}
+}
+
+// @Summary Board collaboration WebSocket
+// @Description WebSocket channel for the board. Client messages: subscribe/unsubscribe {project_id}, view {project_id, task_id}, edit {task_id, patch}; every message may carry a ref that is echoed in the reply. Server messages: subscribed, unsubscribed, presence, edit, edit.applied, edit.rejected, ack, reject, event, heartbeat, error. The connection is closed when the JWT expires. The token may also be passed as the access_token query parameter.
+// @Tags collaboration
+// @Param access_token query string false "JWT for clients that cannot send the Authorization header"
+// @Success 101 {string} string "switching protocols"
+// @Security BearerAuth
+// @Router /collaboration/ws [get]
+func (controller *CollaborationControllerImpl) Connect(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ctx := request.Context()
+	peer := controller.CollaborationService.Connect(ctx)
+
+	server := websocket.Server{
+		// Origin sudah ditangani CORS; autentikasi memakai JWT
+		Handshake: func(config *websocket.Config, request *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			conn.MaxPayloadBytes = collaborationMaxMessageBytes
+			defer controller.CollaborationService.Disconnect(peer)
+			go controller.writeLoop(ctx, conn, peer)
+			controller.readLoop(ctx, conn, peer)
+		},
+	}
+	server.ServeHTTP(writer, request)
+}
+
+// readLoop memproses pesan client sampai koneksi ditutup
+func (controller *CollaborationControllerImpl) readLoop(ctx context.Context, conn *websocket.Conn, peer *event.Peer) {
+	for {
+		var message string
+		if err := websocket.Message.Receive(conn, &message); err != nil {
+			return
+		}
+
+		collaborationRequest := web.CollaborationRequest{}
+		if err := json.Unmarshal([]byte(message), &collaborationRequest); err != nil {
+			var syntaxError *json.SyntaxError
+			var typeError *json.UnmarshalTypeError
+			if errors.As(err, &syntaxError) || errors.As(err, &typeError) {
+				controller.CollaborationService.SendError(peer, "", "invalid message: "+err.Error())
+				continue
+			}
+			return
+		}
+		controller.CollaborationService.Handle(ctx, peer, collaborationRequest)
+	}
+}
+
+// writeLoop adalah satu-satunya penulis koneksi: pesan dari hub, heartbeat,
+// dan penutupan saat token kedaluwarsa atau peer diputus hub.
+func (controller *CollaborationControllerImpl) writeLoop(ctx context.Context, conn *websocket.Conn, peer *event.Peer) {
+	defer conn.Close()
+
+	var expired <-chan time.Time
+	if expiresAt, ok := helper.TokenExpiryFromContext(ctx); ok {
+		timer := time.NewTimer(time.Until(expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	heartbeat := time.NewTicker(collaborationHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	send := func(data []byte) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(collaborationWriteTimeout))
+		return websocket.Message.Send(conn, string(data)) == nil
+	}
+
+	for {
+		select {
+		case <-peer.Done:
+			return
+		case data := <-peer.Send:
+			if !send(data) {
+				return
+			}
+		case <-heartbeat.C:
+			if !send([]byte(`{"type":"heartbeat"}`)) {
+				return
+			}
+		case <-expired:
+			send([]byte(`{"type":"error","error":"token expired, reconnect with a new token"}`))
+			return
+		}
+	}
+}
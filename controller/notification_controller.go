@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type NotificationController interface {
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	MarkRead(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	MarkUnread(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	MarkAllRead(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindPreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdatePreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UnsubscribePage(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Unsubscribe(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
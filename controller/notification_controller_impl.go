@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/mail"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// NotificationControllerImpl adalah implementasi dari NotificationController
+type NotificationControllerImpl struct {
+	NotificationService service.NotificationService
+	Templates           *mail.Templates
+}
+
+// NewNotificationController membuat instance NotificationController baru
+func NewNotificationController(notificationService service.NotificationService, templates *mail.Templates) NotificationController {
+	return &NotificationControllerImpl{
+		NotificationService: notificationService,
+		Templates:           templates,
+	}
+}
+
+// @Summary List notifications
+// @Description Get the in-app notification inbox of the logged-in user, newest first, with the unread count
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param before query string false "Cursor (RFC 3339) from next_before of the previous page"
+// @Param limit query int false "Page size (default 50, max 100)"
+// @Success 200 {object} web.NotificationListResponse
+// @Security BearerAuth
+// @Router /me/notifications [get]
+func (controller *NotificationControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	listRequest := web.NotificationListRequest{
+		UnreadOnly: request.URL.Query().Get("unread") == "true",
+	}
+	if value := request.URL.Query().Get("before"); value != "" {
+		before, err := time.Parse(time.RFC3339Nano, value)
+		helper.PanicIfError(err)
+		listRequest.Before = &before
+	}
+	if value := request.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		helper.PanicIfError(err)
+		listRequest.Limit = limit
+	}
+
+	listResponse := controller.NotificationService.FindAll(request.Context(), listRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   listResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Mark notifications as read
+// @Description Mark the given notifications of the logged-in user as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param notifications body web.NotificationMarkRequest true "Notification IDs"
+// @Success 200 {object} web.NotificationCountResponse
+// @Security BearerAuth
+// @Router /me/notifications/read [post]
+func (controller *NotificationControllerImpl) MarkRead(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	controller.mark(writer, request, true)
+}
+
+// @Summary Mark notifications as unread
+// @Description Mark the given notifications of the logged-in user as unread
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param notifications body web.NotificationMarkRequest true "Notification IDs"
+// @Success 200 {object} web.NotificationCountResponse
+// @Security BearerAuth
+// @Router /me/notifications/unread [post]
+func (controller *NotificationControllerImpl) MarkUnread(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	controller.mark(writer, request, false)
+}
+
+func (controller *NotificationControllerImpl) mark(writer http.ResponseWriter, request *http.Request, read bool) {
+	markRequest := web.NotificationMarkRequest{}
+	helper.ReadFromRequestBody(request, &markRequest)
+
+	countResponse := controller.NotificationService.MarkRead(request.Context(), markRequest, read)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   countResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of the logged-in user as read
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} web.NotificationCountResponse
+// @Security BearerAuth
+// @Router /me/notifications/read-all [post]
+func (controller *NotificationControllerImpl) MarkAllRead(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	countResponse := controller.NotificationService.MarkAllRead(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   countResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Get notification preferences
+// @Description Get the email notification settings of the logged-in user (instant, daily digest or off)
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} web.NotificationPreferenceResponse
+// @Security BearerAuth
+// @Router /me/notification-preferences [get]
+func (controller *NotificationControllerImpl) FindPreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	preferenceResponse := controller.NotificationService.FindPreference(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   preferenceResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Update notification preferences
+// @Description Choose instant emails, a daily digest at digest_hour (profile timezone), or no emails
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param preference body web.NotificationPreferenceUpdateRequest true "Notification preference request"
+// @Success 200 {object} web.NotificationPreferenceResponse
+// @Security BearerAuth
+// @Router /me/notification-preferences [put]
+func (controller *NotificationControllerImpl) UpdatePreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	preferenceUpdateRequest := web.NotificationPreferenceUpdateRequest{}
+	helper.ReadFromRequestBody(request, &preferenceUpdateRequest)
+
+	preferenceResponse := controller.NotificationService.UpdatePreference(request.Context(), preferenceUpdateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   preferenceResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Unsubscribe confirmation page
+// @Description HTML page linked from notification emails; asks the user to confirm unsubscribing (no login required)
+// @Tags public
+// @Produce html
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {string} string "HTML page"
+// @Router /public/unsubscribe/{token} [get]
+func (controller *NotificationControllerImpl) UnsubscribePage(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	// GET tidak mengubah apa pun: pemindai link di email ikut membuka URL ini
+	email := controller.NotificationService.FindUnsubscribe(request.Context(), params.ByName("token"))
+	controller.writePage(writer, mail.UnsubscribePageData{Email: email})
+}
+
+// @Summary Unsubscribe from notification emails
+// @Description Turn off notification emails for the token owner. Also used by mail clients for one-click unsubscribe (RFC 8058).
+// @Tags public
+// @Produce html
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {string} string "HTML page"
+// @Router /public/unsubscribe/{token} [post]
+func (controller *NotificationControllerImpl) Unsubscribe(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	email := controller.NotificationService.Unsubscribe(request.Context(), params.ByName("token"))
+	controller.writePage(writer, mail.UnsubscribePageData{Email: email, Done: true})
+}
+
+func (controller *NotificationControllerImpl) writePage(writer http.ResponseWriter, data mail.UnsubscribePageData) {
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.Header().Set("Cache-Control", "no-store")
+	err := controller.Templates.RenderUnsubscribePage(writer, data)
+	helper.PanicIfError(err)
+}
@@ -12,4 +12,7 @@ type ProfileController interface {
 	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByUserId(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UploadAvatar(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	DeleteAvatar(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Avatar(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
\ No newline at end of file
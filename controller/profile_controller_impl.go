@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
 	"github.com/google/uuid"
 	"github.com/julienschmidt/httprouter"
-	"net/http"
 	"task-management/helper"
 	"task-management/model/web"
 	"task-management/service"
@@ -131,4 +135,85 @@ func (c *ProfileControllerImpl) FindAll(w http.ResponseWriter, r *http.Request,
 		Status: "OK",
 		Data:   responses,
 	})
-}
\ No newline at end of file
+}
+
+// avatarCacheControl: URL avatar di response profile sudah berversi (?v=),
+// jadi browser cukup revalidasi sekali sehari lewat ETag/Last-Modified
+const avatarCacheControl = "public, max-age=86400"
+
+// UploadAvatar menerima multipart form dengan field "avatar"
+func (c *ProfileControllerImpl) UploadAvatar(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userId, err := uuid.Parse(ps.ByName("userId"))
+	if err != nil {
+		helper.WriteToResponseBody(w, web.WebResponse{
+			Code:   400,
+			Status: "BAD REQUEST",
+			Data:   "Invalid user ID",
+		})
+		return
+	}
+
+	// Sisakan ruang untuk header multipart di atas batas ukuran file
+	r.Body = http.MaxBytesReader(w, r.Body, helper.MaxAvatarBytes+1<<20)
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		helper.WriteToResponseBody(w, web.WebResponse{
+			Code:   400,
+			Status: "BAD REQUEST",
+			Data:   "avatar file is required (max 5 MB)",
+		})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	helper.PanicIfError(err)
+
+	response := c.ProfileService.UploadAvatar(r.Context(), userId, data)
+	helper.WriteToResponseBody(w, web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   response,
+	})
+}
+
+func (c *ProfileControllerImpl) DeleteAvatar(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userId, err := uuid.Parse(ps.ByName("userId"))
+	if err != nil {
+		helper.WriteToResponseBody(w, web.WebResponse{
+			Code:   400,
+			Status: "BAD REQUEST",
+			Data:   "Invalid user ID",
+		})
+		return
+	}
+
+	response := c.ProfileService.DeleteAvatar(r.Context(), userId)
+	helper.WriteToResponseBody(w, web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   response,
+	})
+}
+
+// Avatar mengirim file thumbnail avatar (publik, agar bisa dipakai langsung di tag <img>)
+func (c *ProfileControllerImpl) Avatar(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userId, err := uuid.Parse(ps.ByName("userId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	size, err := strconv.Atoi(ps.ByName("size"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, modTime := c.ProfileService.OpenAvatar(userId, size)
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", avatarCacheControl)
+	w.Header().Set("ETag", fmt.Sprintf(`"%s-%d-%d"`, userId, size, modTime.Unix()))
+	http.ServeContent(w, r, "", modTime, file)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// ProjectBurndownControllerImpl adalah implementasi dari ProjectBurndownController
+type ProjectBurndownControllerImpl struct {
+	ProjectBurndownService service.ProjectBurndownService
+}
+
+// NewProjectBurndownController membuat instance ProjectBurndownController baru
+func NewProjectBurndownController(projectBurndownService service.ProjectBurndownService) ProjectBurndownController {
+	return &ProjectBurndownControllerImpl{
+		ProjectBurndownService: projectBurndownService,
+	}
+}
+
+// @Summary Get project burndown
+// @Description Get daily remaining/completed series reconstructed from task status history, with scope changes marked
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param from query string false "Start date (YYYY-MM-DD), default: project start date"
+// @Param to query string false "End date (YYYY-MM-DD), default: today"
+// @Param unit query string false "effort (default) or count"
+// @Success 200 {object} web.ProjectBurndownResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/burndown [get]
+func (controller *ProjectBurndownControllerImpl) Burndown(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	burndownRequest := web.ProjectBurndownRequest{
+		ProjectId: projectId,
+		Unit:      request.URL.Query().Get("unit"),
+	}
+	// Tanggal di query dibaca di zona waktu user
+	location := helper.LocationFromContext(request.Context())
+	if value := request.URL.Query().Get("from"); value != "" {
+		from, err := time.ParseInLocation("2006-01-02", value, location)
+		if err != nil {
+			panic(exception.NewBadRequestError("invalid 'from' date, expected YYYY-MM-DD"))
+		}
+		burndownRequest.From = &from
+	}
+	if value := request.URL.Query().Get("to"); value != "" {
+		to, err := time.ParseInLocation("2006-01-02", value, location)
+		if err != nil {
+			panic(exception.NewBadRequestError("invalid 'to' date, expected YYYY-MM-DD"))
+		}
+		burndownRequest.To = &to
+	}
+
+	burndownResponse := controller.ProjectBurndownService.Burndown(request.Context(), burndownRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   burndownResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -12,4 +12,8 @@ type ProjectController interface {
 	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByUserId(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Clone(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ChangeStatus(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Archive(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Unarchive(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
\ No newline at end of file
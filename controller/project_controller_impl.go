@@ -23,7 +23,7 @@ func NewProjectController(projectService service.ProjectService) ProjectControll
 }
 
 // @Summary Create new project
-// @Description Create new project with the input payload
+// @Description Create new project with the input payload. The project is owned by the caller
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -124,6 +124,7 @@ func (controller *ProjectControllerImpl) FindById(writer http.ResponseWriter, re
 // @Accept json
 // @Produce json
 // @Param userId path string true "User ID"
+// @Param include_archived query bool false "Include archived projects"
 // @Success 200 {array} web.ProjectResponse
 // @Security BearerAuth
 // @Router /projects/user/{userId} [get]
@@ -131,7 +132,8 @@ func (controller *ProjectControllerImpl) FindByUserId(writer http.ResponseWriter
 	userId, err := uuid.Parse(params.ByName("userId"))
 	helper.PanicIfError(err)
 
-	projectResponses := controller.ProjectService.FindByUserId(request.Context(), userId)
+	includeArchived := request.URL.Query().Get("include_archived") == "true"
+	projectResponses := controller.ProjectService.FindByUserId(request.Context(), userId, includeArchived)
 	webResponse := helper.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -146,11 +148,13 @@ func (controller *ProjectControllerImpl) FindByUserId(writer http.ResponseWriter
 // @Tags projects
 // @Accept json
 // @Produce json
+// @Param include_archived query bool false "Include archived projects"
 // @Success 200 {array} web.ProjectResponse
 // @Security BearerAuth
 // @Router /projects [get]
 func (controller *ProjectControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	projectResponses := controller.ProjectService.FindAll(request.Context())
+	includeArchived := request.URL.Query().Get("include_archived") == "true"
+	projectResponses := controller.ProjectService.FindAll(request.Context(), includeArchived)
 	webResponse := helper.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -159,3 +163,103 @@ func (controller *ProjectControllerImpl) FindAll(writer http.ResponseWriter, req
 
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+// @Summary Clone project
+// @Description Deep clone a project with all of its tasks, optionally resetting status/progress and shifting dates. Requires member role on the source; the clone is owned by the caller
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param options body web.ProjectCloneRequest true "Clone options"
+// @Success 200 {object} web.ProjectCloneResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/clone [post]
+func (controller *ProjectControllerImpl) Clone(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectCloneRequest := web.ProjectCloneRequest{}
+	helper.ReadFromRequestBody(request, &projectCloneRequest)
+
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	projectCloneRequest.Id = projectId
+
+	cloneResponse := controller.ProjectService.Clone(request.Context(), projectCloneRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   cloneResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Change project status
+// @Description Move a project to another lifecycle state (planned, active, on-hold, completed, archived)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param status body web.ProjectStatusRequest true "Status request"
+// @Success 200 {object} web.ProjectResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/status [put]
+func (controller *ProjectControllerImpl) ChangeStatus(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectStatusRequest := web.ProjectStatusRequest{}
+	helper.ReadFromRequestBody(request, &projectStatusRequest)
+
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	projectStatusRequest.Id = projectId
+
+	projectResponse := controller.ProjectService.ChangeStatus(request.Context(), projectStatusRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   projectResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Archive project
+// @Description Archive a project, making it read-only and hidden from default listings
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} web.ProjectResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/archive [post]
+func (controller *ProjectControllerImpl) Archive(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	projectResponse := controller.ProjectService.Archive(request.Context(), projectId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   projectResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Unarchive project
+// @Description Restore an archived project to the status it had before archiving
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} web.ProjectResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/unarchive [post]
+func (controller *ProjectControllerImpl) Unarchive(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	projectResponse := controller.ProjectService.Unarchive(request.Context(), projectId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   projectResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
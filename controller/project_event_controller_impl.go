@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/event"
+	"task-management/helper"
+	"task-management/service"
+)
+
+const (
+	// streamHeartbeatInterval menjaga koneksi tetap hidup melewati proxy
+	streamHeartbeatInterval = 15 * time.Second
+	// streamWriteTimeout memutus client yang tidak membaca data
+	streamWriteTimeout = 10 * time.Second
+)
+
+// ProjectEventControllerImpl adalah implementasi dari ProjectEventController
+type ProjectEventControllerImpl struct {
+	ProjectEventService service.ProjectEventService
+}
+
+// NewProjectEventController membuat instance ProjectEventController baru
+func NewProjectEventController(projectEventService service.ProjectEventService) ProjectEventController {
+	return &ProjectEventControllerImpl{
+		ProjectEventService: projectEventService,
+	}
+}
+
+// @Summary Stream project events
+// @Description Server-Sent Events stream of task changes in a project (task.created, task.updated, task.moved, task.deleted). Send Last-Event-ID to resume; a "reset" event means missed events are no longer available and the client should reload. The token may also be passed as the access_token query parameter.
+// @Tags projects
+// @Produce text/event-stream
+// @Param id path string true "Project ID"
+// @Param Last-Event-ID header string false "ID of the last received event"
+// @Param access_token query string false "JWT for clients that cannot send the Authorization header"
+// @Success 200 {string} string "event stream"
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/events [get]
+func (controller *ProjectEventControllerImpl) Stream(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	lastEventId := request.Header.Get("Last-Event-ID")
+	subscription := controller.ProjectEventService.Subscribe(request.Context(), projectId, lastEventId)
+	defer controller.ProjectEventService.Unsubscribe(subscription)
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+
+	responseController := http.NewResponseController(writer)
+	// write mengirim data lalu flush; error berarti client lambat atau sudah putus
+	write := func(data string) bool {
+		_ = responseController.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := fmt.Fprint(writer, data); err != nil {
+			return false
+		}
+		return responseController.Flush() == nil
+	}
+
+	// retry memberi tahu EventSource jeda sebelum reconnect
+	opening := "retry: 3000\n\n"
+	if subscription.Reset {
+		opening += formatStreamEvent(event.StreamEvent{Name: "reset", Data: "{}"})
+	}
+	for _, streamEvent := range subscription.Replay {
+		opening += formatStreamEvent(streamEvent)
+	}
+	if !write(opening) {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-subscription.Done:
+			// Diputus broker karena antrean penuh; client akan reconnect dengan Last-Event-ID
+			return
+		case streamEvent := <-subscription.Events:
+			if !write(formatStreamEvent(streamEvent)) {
+				return
+			}
+		case <-heartbeat.C:
+			if !write(": heartbeat\n\n") {
+				return
+			}
+		}
+	}
+}
+
+// formatStreamEvent menyusun satu event dalam format text/event-stream
+func formatStreamEvent(streamEvent event.StreamEvent) string {
+	var builder strings.Builder
+	if streamEvent.Id != "" {
+		builder.WriteString("id: " + streamEvent.Id + "\n")
+	}
+	builder.WriteString("event: " + streamEvent.Name + "\n")
+	for _, line := range strings.Split(streamEvent.Data, "\n") {
+		builder.WriteString("data: " + line + "\n")
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/service"
+)
+
+// ProjectHealthControllerImpl adalah implementasi dari ProjectHealthController
+type ProjectHealthControllerImpl struct {
+	ProjectHealthService service.ProjectHealthService
+}
+
+// NewProjectHealthController membuat instance ProjectHealthController baru
+func NewProjectHealthController(projectHealthService service.ProjectHealthService) ProjectHealthController {
+	return &ProjectHealthControllerImpl{
+		ProjectHealthService: projectHealthService,
+	}
+}
+
+// @Summary Get project health
+// @Description Score a project green/amber/red from overdue tasks, bottlenecks, stuck tasks, high-priority ratio and carry-overs
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param stuck_days query int false "Days in-progress before a task counts as stuck (default 7)"
+// @Success 200 {object} web.ProjectHealthResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/health [get]
+func (controller *ProjectHealthControllerImpl) Health(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	stuckDays := 0
+	if value := request.URL.Query().Get("stuck_days"); value != "" {
+		stuckDays, err = strconv.Atoi(value)
+		helper.PanicIfError(err)
+	}
+
+	healthResponse := controller.ProjectHealthService.Health(request.Context(), projectId, stuckDays)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   healthResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
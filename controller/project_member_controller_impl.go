@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// ProjectMemberControllerImpl adalah implementasi dari ProjectMemberController
+type ProjectMemberControllerImpl struct {
+	ProjectMemberService service.ProjectMemberService
+}
+
+// NewProjectMemberController membuat instance ProjectMemberController baru
+func NewProjectMemberController(projectMemberService service.ProjectMemberService) ProjectMemberController {
+	return &ProjectMemberControllerImpl{
+		ProjectMemberService: projectMemberService,
+	}
+}
+
+// @Summary List project members
+// @Description Get all members of a project with their roles
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {array} web.ProjectMemberResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/members [get]
+func (controller *ProjectMemberControllerImpl) FindByProjectId(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	memberResponses := controller.ProjectMemberService.FindByProjectId(request.Context(), projectId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Add project member
+// @Description Add a user to a project with a role (owner, maintainer, member, viewer)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param member body web.ProjectMemberCreateRequest true "Add member request"
+// @Success 200 {object} web.ProjectMemberResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/members [post]
+func (controller *ProjectMemberControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	memberCreateRequest := web.ProjectMemberCreateRequest{}
+	helper.ReadFromRequestBody(request, &memberCreateRequest)
+
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	memberCreateRequest.ProjectId = projectId
+
+	memberResponse := controller.ProjectMemberService.Create(request.Context(), memberCreateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Update project member role
+// @Description Change the role of a project member
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param userId path string true "User ID"
+// @Param member body web.ProjectMemberUpdateRequest true "Update member request"
+// @Success 200 {object} web.ProjectMemberResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/members/{userId} [put]
+func (controller *ProjectMemberControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	memberUpdateRequest := web.ProjectMemberUpdateRequest{}
+	helper.ReadFromRequestBody(request, &memberUpdateRequest)
+
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	userId, err := uuid.Parse(params.ByName("userId"))
+	helper.PanicIfError(err)
+	memberUpdateRequest.ProjectId = projectId
+	memberUpdateRequest.UserId = userId
+
+	memberResponse := controller.ProjectMemberService.Update(request.Context(), memberUpdateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Remove project member
+// @Description Remove a member from a project (members may remove themselves)
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} helper.WebResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/members/{userId} [delete]
+func (controller *ProjectMemberControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	userId, err := uuid.Parse(params.ByName("userId"))
+	helper.PanicIfError(err)
+
+	controller.ProjectMemberService.Delete(request.Context(), projectId, userId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
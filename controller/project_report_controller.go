@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type ProjectReportController interface {
+	Report(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
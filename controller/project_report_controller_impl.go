@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/service"
+)
+
+// ProjectReportControllerImpl adalah implementasi dari ProjectReportController
+type ProjectReportControllerImpl struct {
+	ProjectReportService service.ProjectReportService
+}
+
+// NewProjectReportController membuat instance ProjectReportController baru
+func NewProjectReportController(projectReportService service.ProjectReportService) ProjectReportController {
+	return &ProjectReportControllerImpl{
+		ProjectReportService: projectReportService,
+	}
+}
+
+// @Summary Download project status report
+// @Description Render a printable PDF with the project header (progress, confidence, trend), tasks grouped by status, open bottlenecks and status changes of the last 7 days
+// @Tags projects
+// @Produce application/pdf
+// @Param id path string true "Project ID"
+// @Success 200 {string} string "PDF document"
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/report.pdf [get]
+func (controller *ProjectReportControllerImpl) Report(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	document := controller.ProjectReportService.Report(request.Context(), projectId)
+
+	// Dokumen ditulis ke buffer dulu agar Content-Length diketahui
+	var body bytes.Buffer
+	_, err = document.WriteTo(&body)
+	helper.PanicIfError(err)
+
+	writer.Header().Set("Content-Type", "application/pdf")
+	writer.Header().Set("Content-Disposition", `inline; filename="report-`+projectId.String()+`.pdf"`)
+	writer.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	_, err = body.WriteTo(writer)
+	helper.PanicIfError(err)
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type ProjectShareController interface {
+	Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByProjectId(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Revoke(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindShared(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
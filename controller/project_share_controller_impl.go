@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// ProjectShareControllerImpl adalah implementasi dari ProjectShareController
+type ProjectShareControllerImpl struct {
+	ProjectShareService service.ProjectShareService
+}
+
+// NewProjectShareController membuat instance ProjectShareController baru
+func NewProjectShareController(projectShareService service.ProjectShareService) ProjectShareController {
+	return &ProjectShareControllerImpl{
+		ProjectShareService: projectShareService,
+	}
+}
+
+// @Summary Create project share link
+// @Description Create a signed, expiring read-only public link to a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param link body web.ProjectShareLinkCreateRequest false "Share link options"
+// @Success 200 {object} web.ProjectShareLinkResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/share-links [post]
+func (controller *ProjectShareControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	linkCreateRequest := web.ProjectShareLinkCreateRequest{}
+	if request.ContentLength != 0 {
+		helper.ReadFromRequestBody(request, &linkCreateRequest)
+	}
+
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	linkCreateRequest.ProjectId = projectId
+
+	linkResponse := controller.ProjectShareService.Create(request.Context(), linkCreateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   linkResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary List project share links
+// @Description Get all share links of a project, including expired and revoked ones
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {array} web.ProjectShareLinkResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/share-links [get]
+func (controller *ProjectShareControllerImpl) FindByProjectId(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	linkResponses := controller.ProjectShareService.FindByProjectId(request.Context(), projectId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   linkResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Revoke project share link
+// @Description Revoke a share link so its token stops working immediately
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param linkId path string true "Share link ID"
+// @Success 200 {object} web.ProjectShareLinkResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/share-links/{linkId} [delete]
+func (controller *ProjectShareControllerImpl) Revoke(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	linkId, err := uuid.Parse(params.ByName("linkId"))
+	helper.PanicIfError(err)
+
+	linkResponse := controller.ProjectShareService.Revoke(request.Context(), projectId, linkId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   linkResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary View shared project
+// @Description Read-only project summary and task list through a public share token (no login required)
+// @Tags public
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} web.SharedProjectResponse
+// @Router /public/share/{token} [get]
+func (controller *ProjectShareControllerImpl) FindShared(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	sharedResponse := controller.ProjectShareService.FindShared(request.Context(), params.ByName("token"))
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   sharedResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/service"
+)
+
+// ProjectSnapshotControllerImpl adalah implementasi dari ProjectSnapshotController
+type ProjectSnapshotControllerImpl struct {
+	ProjectSnapshotService service.ProjectSnapshotService
+}
+
+// NewProjectSnapshotController membuat instance ProjectSnapshotController baru
+func NewProjectSnapshotController(projectSnapshotService service.ProjectSnapshotService) ProjectSnapshotController {
+	return &ProjectSnapshotControllerImpl{
+		ProjectSnapshotService: projectSnapshotService,
+	}
+}
+
+// @Summary Get project snapshots
+// @Description Get the daily progress snapshot time series of a project (default: last 30 days)
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} web.ProjectSnapshotResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/snapshots [get]
+func (controller *ProjectSnapshotControllerImpl) FindByProjectId(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	// Tanggal di query dibaca di zona waktu user
+	location := helper.LocationFromContext(request.Context())
+	to := helper.Now().In(location)
+	if value := request.URL.Query().Get("to"); value != "" {
+		to, err = time.ParseInLocation("2006-01-02", value, location)
+		helper.PanicIfError(err)
+	}
+	from := to.AddDate(0, 0, -30)
+	if value := request.URL.Query().Get("from"); value != "" {
+		from, err = time.ParseInLocation("2006-01-02", value, location)
+		helper.PanicIfError(err)
+	}
+
+	snapshotResponses := controller.ProjectSnapshotService.FindByProjectId(request.Context(), projectId, from, to)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   snapshotResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
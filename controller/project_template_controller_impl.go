@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// ProjectTemplateControllerImpl adalah implementasi dari ProjectTemplateController
+type ProjectTemplateControllerImpl struct {
+	ProjectTemplateService service.ProjectTemplateService
+}
+
+// NewProjectTemplateController membuat instance ProjectTemplateController baru
+func NewProjectTemplateController(projectTemplateService service.ProjectTemplateService) ProjectTemplateController {
+	return &ProjectTemplateControllerImpl{
+		ProjectTemplateService: projectTemplateService,
+	}
+}
+
+// @Summary Save project as template
+// @Description Save an existing project, with its tasks, as a reusable template
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param template body web.ProjectTemplateCreateRequest true "Create template request"
+// @Success 200 {object} web.ProjectTemplateResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/template [post]
+func (controller *ProjectTemplateControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	templateCreateRequest := web.ProjectTemplateCreateRequest{}
+	helper.ReadFromRequestBody(request, &templateCreateRequest)
+
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	templateCreateRequest.ProjectId = projectId
+
+	templateResponse := controller.ProjectTemplateService.Create(request.Context(), templateCreateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   templateResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Create project from template
+// @Description Create a new project with its tasks from a template, substituting {{placeholders}} in titles. The project is owned by the caller
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param project body web.ProjectFromTemplateRequest true "Instantiate template request"
+// @Success 200 {object} web.ProjectResponse
+// @Security BearerAuth
+// @Router /projects/from-template/{templateId} [post]
+func (controller *ProjectTemplateControllerImpl) Instantiate(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	fromTemplateRequest := web.ProjectFromTemplateRequest{}
+	helper.ReadFromRequestBody(request, &fromTemplateRequest)
+
+	templateId, err := uuid.Parse(params.ByName("templateId"))
+	helper.PanicIfError(err)
+	fromTemplateRequest.TemplateId = templateId
+
+	projectResponse := controller.ProjectTemplateService.Instantiate(request.Context(), fromTemplateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   projectResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Delete template
+// @Description Delete template by ID. Only the creator of the template can delete it
+// @Tags templates
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} map[string]interface{} "response with code and status"
+// @Security BearerAuth
+// @Router /templates/{templateId} [delete]
+func (controller *ProjectTemplateControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	templateId, err := uuid.Parse(params.ByName("templateId"))
+	helper.PanicIfError(err)
+
+	controller.ProjectTemplateService.Delete(request.Context(), templateId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Get template by ID
+// @Description Get template by ID, including its tasks. Templates of other users are only visible when shared
+// @Tags templates
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} web.ProjectTemplateResponse
+// @Security BearerAuth
+// @Router /templates/{templateId} [get]
+func (controller *ProjectTemplateControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	templateId, err := uuid.Parse(params.ByName("templateId"))
+	helper.PanicIfError(err)
+
+	templateResponse := controller.ProjectTemplateService.FindById(request.Context(), templateId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   templateResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Get all templates
+// @Description Get all project templates created by the current user or shared in the workspace
+// @Tags templates
+// @Produce json
+// @Success 200 {array} web.ProjectTemplateResponse
+// @Security BearerAuth
+// @Router /templates [get]
+func (controller *ProjectTemplateControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	templateResponses := controller.ProjectTemplateService.FindAll(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   templateResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
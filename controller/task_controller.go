@@ -12,4 +12,10 @@ type TaskController interface {
 	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByProjectId(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Bulk(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Watch(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Unwatch(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindWatching(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportCsv(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
\ No newline at end of file
@@ -1,7 +1,13 @@
 package controller
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"task-management/helper"
 	"task-management/model/web"
 	"task-management/service"
@@ -161,4 +167,196 @@ func (controller *TaskControllerImpl) FindAll(writer http.ResponseWriter, reques
 	}
 
 	helper.WriteToResponseBody(writer, webResponse)
-}
\ No newline at end of file
+}
+
+// Bulk godoc
+// @Summary Bulk update or delete tasks
+// @Description Apply an update patch or a delete to a list of task IDs or to every task matching a filter, in one transaction
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body web.TaskBulkRequest true "Bulk operation"
+// @Success 200 {object} web.TaskBulkResponse
+// @Security BearerAuth
+// @Router /tasks/bulk [post]
+func (controller *TaskControllerImpl) Bulk(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	taskBulkRequest := web.TaskBulkRequest{}
+	helper.ReadFromRequestBody(request, &taskBulkRequest)
+
+	bulkResponse := controller.TaskService.Bulk(request.Context(), taskBulkRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   bulkResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Watch godoc
+// @Summary Watch a task
+// @Description Subscribe the current user to change notifications of a task
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /tasks/id/{id}/watch [post]
+func (controller *TaskControllerImpl) Watch(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	taskId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	controller.TaskService.Watch(request.Context(), taskId)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Unwatch godoc
+// @Summary Unwatch a task
+// @Description Stop change notifications of a task for the current user
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /tasks/id/{id}/watch [delete]
+func (controller *TaskControllerImpl) Unwatch(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	taskId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	controller.TaskService.Unwatch(request.Context(), taskId)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindWatching godoc
+// @Summary Get watched tasks
+// @Description Get all tasks watched by the current user
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Success 200 {array} web.TaskResponse
+// @Security BearerAuth
+// @Router /me/watching [get]
+func (controller *TaskControllerImpl) FindWatching(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	taskResponses := controller.TaskService.FindWatching(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   taskResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// taskImportMaxBytes membatasi ukuran file CSV yang di-import
+const taskImportMaxBytes = 10 << 20
+
+// csvFlushRows adalah jumlah baris export yang ditulis sebelum dikirim ke client
+const csvFlushRows = 100
+
+// ExportCsv godoc
+// @Summary Export project tasks as CSV
+// @Description Stream all tasks of a project as CSV with one column per task response field
+// @Tags tasks
+// @Produce text/csv
+// @Param projectId path string true "Project ID"
+// @Success 200 {string} string "CSV file"
+// @Security BearerAuth
+// @Router /tasks/project/{projectId}/export.csv [get]
+func (controller *TaskControllerImpl) ExportCsv(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("projectId"))
+	helper.PanicIfError(err)
+
+	taskResponses := controller.TaskService.FindByProjectId(request.Context(), projectId)
+
+	writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	writer.Header().Set("Content-Disposition", `attachment; filename="tasks-`+projectId.String()+`.csv"`)
+	flusher, _ := writer.(http.Flusher)
+
+	csvWriter := csv.NewWriter(writer)
+	helper.PanicIfError(csvWriter.Write(helper.TaskCsvColumns))
+	for i, taskResponse := range taskResponses {
+		if err := csvWriter.Write(helper.ToTaskCsvRecord(taskResponse)); err != nil {
+			// Header sudah terkirim, client yang terputus tidak perlu dilaporkan
+			return
+		}
+		if (i+1)%csvFlushRows == 0 && flusher != nil {
+			csvWriter.Flush()
+			flusher.Flush()
+		}
+	}
+	csvWriter.Flush()
+}
+
+// Import godoc
+// @Summary Import tasks from CSV
+// @Description Create tasks in a project from a CSV file (multipart field "file", or a raw text/csv body). Columns are matched by task field name unless remapped with "mapping". Every row is validated like task creation and reported separately.
+// @Tags tasks
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param file formData file false "CSV file (max 10 MB)"
+// @Param mapping formData string false "JSON object of task field to CSV column, e.g. {\"title\":\"Name\"}"
+// @Param dry_run formData boolean false "Validate only, save nothing"
+// @Param all_or_nothing formData boolean false "Save nothing if any row fails"
+// @Success 200 {object} web.TaskImportResponse
+// @Security BearerAuth
+// @Router /projects/by-id/{id}/import [post]
+func (controller *TaskControllerImpl) Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	// Opsi dibaca dari form multipart, atau dari query string jika body berisi CSV langsung
+	request.Body = http.MaxBytesReader(writer, request.Body, taskImportMaxBytes)
+	var file io.Reader = request.Body
+	options := request.URL.Query()
+	if strings.HasPrefix(request.Header.Get("Content-Type"), "multipart/form-data") {
+		formFile, _, err := request.FormFile("file")
+		if err != nil {
+			helper.WriteToResponseBody(writer, web.WebResponse{
+				Code:   400,
+				Status: "BAD REQUEST",
+				Data:   "file is required (CSV, max 10 MB)",
+			})
+			return
+		}
+		defer formFile.Close()
+		file = formFile
+		options = url.Values(request.MultipartForm.Value)
+	}
+
+	importRequest := web.TaskImportRequest{ProjectId: projectId}
+	importRequest.DryRun, _ = strconv.ParseBool(options.Get("dry_run"))
+	importRequest.AllOrNothing, _ = strconv.ParseBool(options.Get("all_or_nothing"))
+	if mapping := options.Get("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &importRequest.Mapping); err != nil {
+			helper.WriteToResponseBody(writer, web.WebResponse{
+				Code:   400,
+				Status: "BAD REQUEST",
+				Data:   "mapping must be a JSON object of task field to CSV column",
+			})
+			return
+		}
+	}
+
+	importResponse := controller.TaskService.Import(request.Context(), importRequest, file)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   importResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type TrashController interface {
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	RestoreProject(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	RestoreTask(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/service"
+)
+
+// TrashControllerImpl adalah implementasi dari TrashController
+type TrashControllerImpl struct {
+	TrashService service.TrashService
+}
+
+// NewTrashController membuat instance TrashController baru
+func NewTrashController(trashService service.TrashService) TrashController {
+	return &TrashControllerImpl{
+		TrashService: trashService,
+	}
+}
+
+// @Summary List trash
+// @Description Get soft-deleted projects and tasks the current user can restore
+// @Tags trash
+// @Produce json
+// @Success 200 {array} web.TrashItemResponse
+// @Security BearerAuth
+// @Router /trash [get]
+func (controller *TrashControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	trashResponses := controller.TrashService.FindAll(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   trashResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Restore project
+// @Description Restore a soft-deleted project from the trash
+// @Tags trash
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} web.ProjectResponse
+// @Security BearerAuth
+// @Router /trash/projects/{id}/restore [post]
+func (controller *TrashControllerImpl) RestoreProject(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	projectId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	projectResponse := controller.TrashService.RestoreProject(request.Context(), projectId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   projectResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Restore task
+// @Description Restore a soft-deleted task from the trash
+// @Tags trash
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {object} web.TaskResponse
+// @Security BearerAuth
+// @Router /trash/tasks/{id}/restore [post]
+func (controller *TrashControllerImpl) RestoreTask(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	taskId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	taskResponse := controller.TrashService.RestoreTask(request.Context(), taskId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   taskResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// WebhookControllerImpl adalah implementasi dari WebhookController
+type WebhookControllerImpl struct {
+	WebhookService service.WebhookService
+}
+
+// NewWebhookController membuat instance WebhookController baru
+func NewWebhookController(webhookService service.WebhookService) WebhookController {
+	return &WebhookControllerImpl{
+		WebhookService: webhookService,
+	}
+}
+
+// @Summary Create webhook
+// @Description Subscribe a URL to task/project events of one project or the whole workspace. Workspace webhooks are managed only by their creator and receive events of projects the creator can view. The signing secret is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body web.WebhookCreateRequest true "Create webhook request"
+// @Success 200 {object} web.WebhookResponse
+// @Security BearerAuth
+// @Router /webhooks [post]
+func (controller *WebhookControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookCreateRequest := web.WebhookCreateRequest{}
+	helper.ReadFromRequestBody(request, &webhookCreateRequest)
+
+	webhookResponse := controller.WebhookService.Create(request.Context(), webhookCreateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Update webhook
+// @Description Change the URL, subscribed events or active state of a webhook
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param webhook body web.WebhookUpdateRequest true "Update webhook request"
+// @Success 200 {object} web.WebhookResponse
+// @Security BearerAuth
+// @Router /webhooks/{id} [put]
+func (controller *WebhookControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookUpdateRequest := web.WebhookUpdateRequest{}
+	helper.ReadFromRequestBody(request, &webhookUpdateRequest)
+
+	webhookId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	webhookUpdateRequest.Id = webhookId
+
+	webhookResponse := controller.WebhookService.Update(request.Context(), webhookUpdateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Delete webhook
+// @Description Delete a webhook and its delivery log
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} map[string]interface{} "response with code and status"
+// @Security BearerAuth
+// @Router /webhooks/{id} [delete]
+func (controller *WebhookControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	controller.WebhookService.Delete(request.Context(), webhookId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Get webhook by ID
+// @Description Get a webhook by ID
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} web.WebhookResponse
+// @Security BearerAuth
+// @Router /webhooks/{id} [get]
+func (controller *WebhookControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	webhookResponse := controller.WebhookService.FindById(request.Context(), webhookId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary List webhooks
+// @Description Get all webhooks in the workspace that the user can manage
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} web.WebhookResponse
+// @Security BearerAuth
+// @Router /webhooks [get]
+func (controller *WebhookControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookResponses := controller.WebhookService.FindAll(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary List webhook deliveries
+// @Description Get the most recent deliveries of a webhook with their payload and last result
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {array} web.WebhookDeliveryResponse
+// @Security BearerAuth
+// @Router /webhooks/{id}/deliveries [get]
+func (controller *WebhookControllerImpl) FindDeliveries(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+
+	deliveryResponses := controller.WebhookService.FindDeliveries(request.Context(), webhookId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   deliveryResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Redeliver webhook delivery
+// @Description Queue the payload of a previous delivery to be sent again
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} web.WebhookDeliveryResponse
+// @Security BearerAuth
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (controller *WebhookControllerImpl) Redeliver(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookId, err := uuid.Parse(params.ByName("id"))
+	helper.PanicIfError(err)
+	deliveryId, err := uuid.Parse(params.ByName("deliveryId"))
+	helper.PanicIfError(err)
+
+	deliveryResponse := controller.WebhookService.Redeliver(request.Context(), webhookId, deliveryId)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   deliveryResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type WorkspaceController interface {
+	FindCurrent(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateCurrent(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
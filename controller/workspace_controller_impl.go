@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"task-management/helper"
+	"task-management/model/web"
+	"task-management/service"
+)
+
+// WorkspaceControllerImpl adalah implementasi dari WorkspaceController
+type WorkspaceControllerImpl struct {
+	WorkspaceService service.WorkspaceService
+}
+
+// NewWorkspaceController membuat instance WorkspaceController baru
+func NewWorkspaceController(workspaceService service.WorkspaceService) WorkspaceController {
+	return &WorkspaceControllerImpl{
+		WorkspaceService: workspaceService,
+	}
+}
+
+// @Summary Get current workspace
+// @Description Get the workspace (tenant) of the logged-in user
+// @Tags workspaces
+// @Produce json
+// @Success 200 {object} web.WorkspaceResponse
+// @Security BearerAuth
+// @Router /workspace [get]
+func (controller *WorkspaceControllerImpl) FindCurrent(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	workspaceResponse := controller.WorkspaceService.FindCurrent(request.Context())
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   workspaceResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary Update current workspace
+// @Description Rename the workspace of the logged-in user
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspace body web.WorkspaceUpdateRequest true "Update workspace request"
+// @Success 200 {object} web.WorkspaceResponse
+// @Security BearerAuth
+// @Router /workspace [put]
+func (controller *WorkspaceControllerImpl) UpdateCurrent(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	workspaceUpdateRequest := web.WorkspaceUpdateRequest{}
+	helper.ReadFromRequestBody(request, &workspaceUpdateRequest)
+
+	workspaceResponse := controller.WorkspaceService.UpdateCurrent(request.Context(), workspaceUpdateRequest)
+	webResponse := helper.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   workspaceResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,217 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+const (
+	// brokerReplaySize adalah jumlah event terakhir per project yang disimpan untuk resume
+	brokerReplaySize = 256
+	// brokerSubscriberBuffer adalah antrean per subscriber; jika penuh, subscriber diputus
+	brokerSubscriberBuffer = 64
+	// brokerIdleRetention menahan buffer project tanpa subscriber agar client bisa resume
+	brokerIdleRetention = 5 * time.Minute
+)
+
+// StreamEvent adalah satu event untuk client stream (SSE). Id berbentuk
+// "<epoch>-<urutan>" sehingga Last-Event-ID dari proses sebelumnya dikenali.
+type StreamEvent struct {
+	Id   string
+	Name string
+	Data string
+	seq  uint64
+}
+
+// Subscription adalah satu client yang mendengarkan event sebuah project.
+// Done ditutup jika client terlalu lambat atau berhenti berlangganan.
+type Subscription struct {
+	Events <-chan StreamEvent
+	Done   <-chan struct{}
+	// Replay berisi event yang terlewat sejak Last-Event-ID
+	Replay []StreamEvent
+	// Reset true jika Last-Event-ID tidak lagi ada di buffer; client perlu memuat ulang data
+	Reset bool
+
+	projectId uuid.UUID
+	events    chan StreamEvent
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+type projectStream struct {
+	buffer      []StreamEvent
+	subscribers map[*Subscription]struct{}
+	idleSince   time.Time
+	// since adalah urutan terakhir sebelum buffer ini dibuat; evicted adalah
+	// urutan event terakhir yang sudah dibuang dari buffer
+	since   uint64
+	evicted uint64
+}
+
+// Broker menyebarkan event task ke client stream per project. Broker adalah
+// sink outbox, jadi hanya perubahan yang sudah ter-commit yang dikirim.
+type Broker struct {
+	mutex    sync.Mutex
+	epoch    string
+	sequence uint64
+	projects map[uuid.UUID]*projectStream
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		epoch:    strconv.FormatInt(time.Now().UnixNano(), 36),
+		projects: map[uuid.UUID]*projectStream{},
+	}
+}
+
+func (b *Broker) Name() string { return "stream" }
+
+// streamEventNames memetakan event outbox ke nama event stream
+var streamEventNames = map[string]string{
+	domain.EventTaskCreated:       "task.created",
+	domain.EventTaskUpdated:       "task.updated",
+	domain.EventTaskStatusChanged: "task.moved",
+	domain.EventTaskDeleted:       "task.deleted",
+}
+
+func (b *Broker) Handle(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) error {
+	name, ok := streamEventNames[event.EventType]
+	if !ok {
+		return nil
+	}
+	var message struct {
+		ProjectId *uuid.UUID `json:"project_id"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &message); err != nil || message.ProjectId == nil {
+		return nil
+	}
+	b.publish(*message.ProjectId, name, event.Payload)
+	return nil
+}
+
+func (b *Broker) publish(projectId uuid.UUID, name string, data string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Project yang tidak pernah didengarkan tidak perlu buffer
+	stream, ok := b.projects[projectId]
+	if !ok {
+		return
+	}
+
+	b.sequence++
+	streamEvent := StreamEvent{
+		Id:   b.epoch + "-" + strconv.FormatUint(b.sequence, 10),
+		Name: name,
+		Data: data,
+		seq:  b.sequence,
+	}
+	stream.buffer = append(stream.buffer, streamEvent)
+	if len(stream.buffer) > brokerReplaySize {
+		stream.evicted = stream.buffer[0].seq
+		stream.buffer = stream.buffer[1:]
+	}
+
+	for subscription := range stream.subscribers {
+		select {
+		case subscription.events <- streamEvent:
+		default:
+			// Client lambat diputus agar tidak menahan client lain
+			delete(stream.subscribers, subscription)
+			subscription.close()
+		}
+	}
+	if len(stream.subscribers) == 0 && stream.idleSince.IsZero() {
+		stream.idleSince = time.Now()
+	}
+}
+
+// Subscribe mendaftarkan client untuk project. lastEventId kosong berarti
+// mulai dari event berikutnya tanpa replay.
+func (b *Broker) Subscribe(projectId uuid.UUID, lastEventId string) *Subscription {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.pruneIdle()
+
+	events := make(chan StreamEvent, brokerSubscriberBuffer)
+	done := make(chan struct{})
+	subscription := &Subscription{
+		Events:    events,
+		Done:      done,
+		projectId: projectId,
+		events:    events,
+		done:      done,
+	}
+
+	stream, ok := b.projects[projectId]
+	if !ok {
+		stream = &projectStream{subscribers: map[*Subscription]struct{}{}, since: b.sequence}
+		b.projects[projectId] = stream
+	}
+	stream.subscribers[subscription] = struct{}{}
+	stream.idleSince = time.Time{}
+
+	if lastEventId != "" {
+		subscription.Replay, subscription.Reset = b.replay(stream, lastEventId)
+	}
+	return subscription
+}
+
+// replay mengembalikan event setelah lastEventId, atau reset jika event
+// tersebut dari proses lain atau sudah keluar dari buffer
+func (b *Broker) replay(stream *projectStream, lastEventId string) ([]StreamEvent, bool) {
+	epoch, sequenceString, ok := strings.Cut(lastEventId, "-")
+	sequence, err := strconv.ParseUint(sequenceString, 10, 64)
+	if !ok || err != nil || epoch != b.epoch {
+		return nil, true
+	}
+	// Event project ini sesudah lastEventId mungkin sudah dibuang atau
+	// tidak pernah di-buffer (sebelum ada subscriber)
+	if sequence < stream.since || sequence < stream.evicted {
+		return nil, true
+	}
+
+	var missed []StreamEvent
+	for _, streamEvent := range stream.buffer {
+		if streamEvent.seq > sequence {
+			missed = append(missed, streamEvent)
+		}
+	}
+	return missed, false
+}
+
+func (b *Broker) Unsubscribe(subscription *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if stream, ok := b.projects[subscription.projectId]; ok {
+		delete(stream.subscribers, subscription)
+		if len(stream.subscribers) == 0 {
+			stream.idleSince = time.Now()
+		}
+	}
+	subscription.close()
+}
+
+// pruneIdle membuang buffer project yang sudah lama tidak punya subscriber
+func (b *Broker) pruneIdle() {
+	for projectId, stream := range b.projects {
+		if len(stream.subscribers) == 0 && !stream.idleSince.IsZero() && time.Since(stream.idleSince) > brokerIdleRetention {
+			delete(b.projects, projectId)
+		}
+	}
+}
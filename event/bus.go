@@ -0,0 +1,46 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"task-management/model/domain"
+)
+
+// Handler memproses satu event di dalam transaksi publisher. Handler yang
+// gagal cukup panic (helper.PanicIfError) sehingga seluruh transaksi di-rollback.
+type Handler func(ctx context.Context, tx *sql.Tx, event domain.Event)
+
+// Bus adalah event bus in-process yang sinkron: Publish baru kembali setelah
+// semua handler untuk event tersebut selesai dijalankan.
+type Bus struct {
+	mutex    sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+// Subscribe mendaftarkan handler untuk nama event tertentu (lihat domain.EventXxx)
+func (b *Bus) Subscribe(eventName string, handler Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.handlers[eventName] = append(b.handlers[eventName], handler)
+}
+
+// Publish menjalankan handler sesuai urutan Subscribe. Bus nil diabaikan,
+// sehingga service tetap bisa dipakai tanpa event bus (mis. di command line).
+func (b *Bus) Publish(ctx context.Context, tx *sql.Tx, event domain.Event) {
+	if b == nil {
+		return
+	}
+	b.mutex.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, tx, event)
+	}
+}
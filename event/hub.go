@@ -0,0 +1,231 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+	"task-management/model/web"
+)
+
+// hubPeerBuffer adalah antrean pesan per koneksi; jika penuh, koneksi diputus
+const hubPeerBuffer = 64
+
+// Peer adalah satu koneksi WebSocket kolaborasi. Send dibaca oleh penulis
+// koneksi; Done ditutup saat koneksi harus diakhiri (disconnect atau lambat).
+type Peer struct {
+	Id     uuid.UUID
+	UserId uuid.UUID
+	Send   <-chan []byte
+	Done   <-chan struct{}
+
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	// projects berisi project yang diikuti beserta task yang sedang dilihat (nil = board)
+	projects map[uuid.UUID]*uuid.UUID
+}
+
+func (p *Peer) close() {
+	p.closeOnce.Do(func() { close(p.done) })
+}
+
+// Hub menyimpan koneksi kolaborasi per project dan presence (siapa melihat
+// task apa). Hub juga sink outbox sehingga perubahan task yang sudah
+// ter-commit ikut diteruskan ke semua koneksi project tersebut.
+type Hub struct {
+	mutex sync.Mutex
+	rooms map[uuid.UUID]map[*Peer]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{rooms: map[uuid.UUID]map[*Peer]struct{}{}}
+}
+
+func (h *Hub) Connect(userId uuid.UUID) *Peer {
+	send := make(chan []byte, hubPeerBuffer)
+	done := make(chan struct{})
+	return &Peer{
+		Id:       uuid.New(),
+		UserId:   userId,
+		Send:     send,
+		Done:     done,
+		send:     send,
+		done:     done,
+		projects: map[uuid.UUID]*uuid.UUID{},
+	}
+}
+
+// Disconnect mengeluarkan peer dari semua project dan mengabarkan presence baru
+func (h *Hub) Disconnect(peer *Peer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for projectId := range peer.projects {
+		h.leave(peer, projectId)
+	}
+	peer.close()
+}
+
+// Join memasukkan peer ke project dan mengembalikan presence terkini
+func (h *Hub) Join(peer *Peer, projectId uuid.UUID) []web.PresenceResponse {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	room, ok := h.rooms[projectId]
+	if !ok {
+		room = map[*Peer]struct{}{}
+		h.rooms[projectId] = room
+	}
+	if _, joined := peer.projects[projectId]; !joined {
+		room[peer] = struct{}{}
+		peer.projects[projectId] = nil
+		h.broadcastPresence(projectId)
+	}
+	return h.presence(projectId)
+}
+
+func (h *Hub) Leave(peer *Peer, projectId uuid.UUID) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.leave(peer, projectId)
+}
+
+func (h *Hub) leave(peer *Peer, projectId uuid.UUID) {
+	if _, joined := peer.projects[projectId]; !joined {
+		return
+	}
+	delete(peer.projects, projectId)
+	if room, ok := h.rooms[projectId]; ok {
+		delete(room, peer)
+		if len(room) == 0 {
+			delete(h.rooms, projectId)
+			return
+		}
+	}
+	h.broadcastPresence(projectId)
+}
+
+// Joined memeriksa apakah peer sudah subscribe ke project
+func (h *Hub) Joined(peer *Peer, projectId uuid.UUID) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	_, joined := peer.projects[projectId]
+	return joined
+}
+
+// View mencatat task yang sedang dilihat peer (nil = board) lalu mengabarkan presence.
+// Mengembalikan false jika peer belum subscribe ke project.
+func (h *Hub) View(peer *Peer, projectId uuid.UUID, taskId *uuid.UUID) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, joined := peer.projects[projectId]; !joined {
+		return false
+	}
+	peer.projects[projectId] = taskId
+	h.broadcastPresence(projectId)
+	return true
+}
+
+// SendTo mengirim pesan ke satu peer
+func (h *Hub) SendTo(peer *Peer, message web.CollaborationMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.deliver(peer, data)
+}
+
+// Broadcast mengirim pesan ke semua peer di project kecuali except (boleh nil)
+func (h *Hub) Broadcast(projectId uuid.UUID, message web.CollaborationMessage, except *Peer) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.broadcast(projectId, data, except)
+}
+
+func (h *Hub) broadcast(projectId uuid.UUID, data []byte, except *Peer) {
+	for peer := range h.rooms[projectId] {
+		if peer != except {
+			h.deliver(peer, data)
+		}
+	}
+}
+
+// deliver tidak pernah menunggu: peer yang antreannya penuh diputus
+func (h *Hub) deliver(peer *Peer, data []byte) {
+	select {
+	case <-peer.done:
+	case peer.send <- data:
+	default:
+		peer.close()
+	}
+}
+
+func (h *Hub) broadcastPresence(projectId uuid.UUID) {
+	data, err := json.Marshal(web.CollaborationMessage{
+		Type:      "presence",
+		ProjectId: &projectId,
+		Presence:  h.presence(projectId),
+	})
+	if err != nil {
+		return
+	}
+	h.broadcast(projectId, data, nil)
+}
+
+// presence mengelompokkan peer per user dan task (satu user bisa membuka beberapa tab)
+func (h *Hub) presence(projectId uuid.UUID) []web.PresenceResponse {
+	seen := map[string]bool{}
+	presence := []web.PresenceResponse{}
+	for peer := range h.rooms[projectId] {
+		taskId := peer.projects[projectId]
+		key := peer.UserId.String()
+		if taskId != nil {
+			key += "/" + taskId.String()
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		presence = append(presence, web.PresenceResponse{UserId: peer.UserId, TaskId: taskId})
+	}
+	sort.Slice(presence, func(i, j int) bool {
+		return presence[i].UserId.String() < presence[j].UserId.String()
+	})
+	return presence
+}
+
+func (h *Hub) Name() string { return "collaboration" }
+
+func (h *Hub) Handle(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) error {
+	name, ok := streamEventNames[event.EventType]
+	if !ok {
+		return nil
+	}
+	var message struct {
+		ProjectId *uuid.UUID `json:"project_id"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &message); err != nil || message.ProjectId == nil {
+		return nil
+	}
+	h.Broadcast(*message.ProjectId, web.CollaborationMessage{
+		Type:      "event",
+		ProjectId: message.ProjectId,
+		Event:     name,
+		Data:      json.RawMessage(event.Payload),
+	}, nil)
+	return nil
+}
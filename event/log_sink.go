@@ -0,0 +1,40 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"task-management/model/domain"
+)
+
+// LogSink menulis setiap event sebagai satu baris JSON ke file (append)
+type LogSink struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func NewLogSink(path string) (*LogSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &LogSink{file: file}, nil
+}
+
+func (s *LogSink) Name() string { return "log" }
+
+func (s *LogSink) Handle(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.file.WriteString(event.Payload + "\n"); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
@@ -0,0 +1,137 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"task-management/model/domain"
+)
+
+const natsTimeout = 5 * time.Second
+
+// NatsSink mem-publish event ke NATS dengan subject "<prefix>.<tipe event>",
+// mis. "task-management.task.created". Protokol teks NATS cukup sederhana
+// sehingga tidak perlu client library: setiap PUB diikuti PING, dan event
+// dianggap terkirim setelah server membalas PONG.
+type NatsSink struct {
+	mutex         sync.Mutex
+	serverUrl     *url.URL
+	subjectPrefix string
+	conn          net.Conn
+	reader        *bufio.Reader
+}
+
+func NewNatsSink(serverUrl string, subjectPrefix string) (*NatsSink, error) {
+	parsed, err := url.Parse(serverUrl)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid NATS url %q", serverUrl)
+	}
+	return &NatsSink{serverUrl: parsed, subjectPrefix: subjectPrefix}, nil
+}
+
+func (s *NatsSink) Name() string { return "nats" }
+
+func (s *NatsSink) Handle(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.publish(event)
+	if err != nil && s.conn != nil {
+		// Koneksi yang error ditutup; percobaan berikutnya membuat koneksi baru
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *NatsSink) publish(event domain.OutboxEvent) error {
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	if err := s.conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		return err
+	}
+
+	subject := s.subjectPrefix + "." + event.EventType
+	message := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(event.Payload), event.Payload)
+	if _, err := s.conn.Write([]byte(message)); err != nil {
+		return err
+	}
+	return s.waitPong()
+}
+
+func (s *NatsSink) connect() error {
+	conn, err := net.DialTimeout("tcp", s.serverUrl.Host, natsTimeout)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+	if err := conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		return err
+	}
+
+	// Server selalu mengirim INFO lebih dulu
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected NATS greeting: %s", strings.TrimSpace(line))
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "task-management-outbox",
+	}
+	if user := s.serverUrl.User; user != nil {
+		options["user"] = user.Username()
+		if password, ok := user.Password(); ok {
+			options["pass"] = password
+		}
+	}
+	connectOptions, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte("CONNECT " + string(connectOptions) + "\r\nPING\r\n")); err != nil {
+		return err
+	}
+	return s.waitPong()
+}
+
+// waitPong membaca balasan server sampai PONG; -ERR berarti perintah ditolak
+func (s *NatsSink) waitPong() error {
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("NATS: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+
+	"task-management/model/domain"
+)
+
+// Sink adalah tujuan event dari outbox relay. Handle dipanggil di dalam
+// transaksi relay: sink yang menulis ke database (mis. webhook) ikut
+// tersimpan atomik bersama status terkirim event. Sink luar (NATS, file)
+// bisa menerima event yang sama lebih dari sekali (at-least-once), jadi
+// penerima sebaiknya deduplikasi berdasarkan ID event.
+type Sink interface {
+	Name() string
+	Handle(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) error
+}
@@ -0,0 +1,11 @@
+package exception
+
+// BadRequestError dipakai untuk input yang tidak lolos validasi di luar
+// validator struct, misalnya file upload yang bukan gambar
+type BadRequestError struct {
+	Error string
+}
+
+func NewBadRequestError(error string) BadRequestError {
+	return BadRequestError{Error: error}
+}
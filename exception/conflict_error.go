@@ -0,0 +1,11 @@
+package exception
+
+// ConflictError dipakai saat request bertentangan dengan kondisi resource,
+// misalnya mengubah project yang sudah diarsipkan
+type ConflictError struct {
+	Error string
+}
+
+func NewConflictError(error string) ConflictError {
+	return ConflictError{Error: error}
+}
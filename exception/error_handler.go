@@ -0,0 +1,93 @@
+package exception
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"task-management/helper"
+	"task-management/model/web"
+)
+
+// ErrorHandler dipasang sebagai PanicHandler router, mengubah panic dari
+// service (helper.PanicIfError) menjadi response JSON dengan status yang sesuai.
+func ErrorHandler(writer http.ResponseWriter, request *http.Request, err interface{}) {
+	if validationErrors(writer, request, err) {
+		return
+	}
+	if badRequestError(writer, request, err) {
+		return
+	}
+	if forbiddenError(writer, request, err) {
+		return
+	}
+	if notFoundError(writer, request, err) {
+		return
+	}
+	if conflictError(writer, request, err) {
+		return
+	}
+	internalServerError(writer, request, err)
+}
+
+func validationErrors(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return false
+	}
+	writeError(writer, http.StatusBadRequest, "BAD REQUEST", exception.Error())
+	return true
+}
+
+func badRequestError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(BadRequestError)
+	if !ok {
+		return false
+	}
+	writeError(writer, http.StatusBadRequest, "BAD REQUEST", exception.Error)
+	return true
+}
+
+func forbiddenError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(ForbiddenError)
+	if !ok {
+		return false
+	}
+	writeError(writer, http.StatusForbidden, "FORBIDDEN", exception.Error)
+	return true
+}
+
+func notFoundError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(NotFoundError)
+	if !ok {
+		return false
+	}
+	writeError(writer, http.StatusNotFound, "NOT FOUND", exception.Error)
+	return true
+}
+
+func conflictError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(ConflictError)
+	if !ok {
+		return false
+	}
+	writeError(writer, http.StatusConflict, "CONFLICT", exception.Error)
+	return true
+}
+
+// internalServerError mencatat error asli di log server. Klien hanya menerima
+// pesan umum karena error database/driver bisa membocorkan nama constraint, role, dll.
+func internalServerError(writer http.ResponseWriter, request *http.Request, err interface{}) {
+	fmt.Printf("⚠️ %s %s failed: %v\n", request.Method, request.URL.Path, err)
+	writeError(writer, http.StatusInternalServerError, "INTERNAL SERVER ERROR", "INTERNAL SERVER ERROR")
+}
+
+func writeError(writer http.ResponseWriter, code int, status string, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(code)
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   code,
+		Status: status,
+		Data:   message,
+	})
+}
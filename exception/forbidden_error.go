@@ -0,0 +1,10 @@
+package exception
+
+// ForbiddenError dipakai saat user tidak punya hak akses ke sebuah resource
+type ForbiddenError struct {
+	Error string
+}
+
+func NewForbiddenError(error string) ForbiddenError {
+	return ForbiddenError{Error: error}
+}
@@ -0,0 +1,10 @@
+package exception
+
+// NotFoundError dipakai saat resource yang diminta tidak ada
+type NotFoundError struct {
+	Error string
+}
+
+func NewNotFoundError(error string) NotFoundError {
+	return NotFoundError{Error: error}
+}
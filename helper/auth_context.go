@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const (
+	userIdContextKey      contextKey = "user_id"
+	tokenExpiryContextKey contextKey = "token_expiry"
+)
+
+// ContextWithUserId menyimpan ID user yang sedang login ke dalam context
+func ContextWithUserId(ctx context.Context, userId uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIdContextKey, userId)
+}
+
+// UserIdFromContext mengambil ID user yang sedang login dari context
+func UserIdFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userId, ok := ctx.Value(userIdContextKey).(uuid.UUID)
+	return userId, ok && userId != uuid.Nil
+}
+
+// ContextWithTokenExpiry menyimpan waktu kedaluwarsa JWT request ke dalam context
+func ContextWithTokenExpiry(ctx context.Context, expiresAt time.Time) context.Context {
+	return context.WithValue(ctx, tokenExpiryContextKey, expiresAt)
+}
+
+// TokenExpiryFromContext mengambil waktu kedaluwarsa JWT; false jika token tidak punya exp
+func TokenExpiryFromContext(ctx context.Context) (time.Time, bool) {
+	expiresAt, ok := ctx.Value(tokenExpiryContextKey).(time.Time)
+	return expiresAt, ok
+}
@@ -0,0 +1,128 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	// MaxAvatarBytes batas ukuran file avatar yang di-upload
+	MaxAvatarBytes = 5 << 20
+	// maxAvatarDimension mencegah decompression bomb sebelum gambar di-decode penuh
+	maxAvatarDimension = 4096
+)
+
+// AvatarSizes adalah ukuran thumbnail persegi (pixel) yang dibuat untuk setiap avatar
+var AvatarSizes = []int{32, 64, 128, 256}
+
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ProcessAvatar memvalidasi gambar lalu membuat thumbnail PNG persegi untuk
+// setiap ukuran di sizes. Foto JPEG diputar sesuai EXIF Orientation, lalu
+// di-crop di tengah agar persegi. Karena hasilnya di-encode ulang dari pixel,
+// metadata asli (EXIF, lokasi GPS, dll) ikut terbuang.
+func ProcessAvatar(data []byte, sizes []int) (map[int][]byte, error) {
+	if len(data) > MaxAvatarBytes {
+		return nil, fmt.Errorf("avatar must be at most %d MB", MaxAvatarBytes>>20)
+	}
+	if !avatarContentTypes[http.DetectContentType(data)] {
+		return nil, errors.New("avatar must be a JPEG, PNG or GIF image")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("avatar is not a valid image")
+	}
+	if config.Width > maxAvatarDimension || config.Height > maxAvatarDimension {
+		return nil, fmt.Errorf("avatar must be at most %dx%d pixels", maxAvatarDimension, maxAvatarDimension)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("avatar is not a valid image")
+	}
+	// Putar sesuai EXIF dulu agar crop diambil dari gambar yang sudah tegak
+	square := cropSquare(applyOrientation(source, jpegOrientation(data)))
+
+	thumbnails := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		var buffer bytes.Buffer
+		if err := png.Encode(&buffer, resizeBox(square, size)); err != nil {
+			return nil, err
+		}
+		thumbnails[size] = buffer.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// cropSquare mengambil bagian tengah gambar dengan sisi terpendek,
+// dikonversi ke RGBA agar pixel-nya bisa dibaca langsung
+func cropSquare(source image.Image) *image.RGBA {
+	bounds := source.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	origin := image.Point{
+		X: bounds.Min.X + (bounds.Dx()-side)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-side)/2,
+	}
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), source, origin, draw.Src)
+	return square
+}
+
+// resizeBox mengubah ukuran gambar persegi dengan rata-rata area (box filter).
+// Untuk pengecilan hasilnya halus tanpa aliasing; untuk pembesaran setara nearest-neighbor.
+func resizeBox(source *image.RGBA, size int) *image.RGBA {
+	side := source.Bounds().Dx()
+	target := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		y0, y1 := boxSpan(y, size, side)
+		for x := 0; x < size; x++ {
+			x0, x1 := boxSpan(x, size, side)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				offset := source.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					sum[0] += int(source.Pix[offset])
+					sum[1] += int(source.Pix[offset+1])
+					sum[2] += int(source.Pix[offset+2])
+					sum[3] += int(source.Pix[offset+3])
+					offset += 4
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := target.PixOffset(x, y)
+			for channel := 0; channel < 4; channel++ {
+				target.Pix[offset+channel] = uint8((sum[channel] + count/2) / count)
+			}
+		}
+	}
+	return target
+}
+
+// boxSpan menghitung rentang pixel sumber [start, end) untuk pixel tujuan ke-i
+func boxSpan(i int, size int, side int) (int, int) {
+	start := i * side / size
+	end := (i + 1) * side / size
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
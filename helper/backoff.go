@@ -0,0 +1,16 @@
+package helper
+
+import "time"
+
+// Backoff menghitung jeda exponential sebelum percobaan ulang: base, 2×base,
+// 4×base, ... dengan batas maksimal max. attempts dihitung mulai dari 1.
+func Backoff(base time.Duration, max time.Duration, attempts int) time.Duration {
+	backoff := base
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= max {
+			return max
+		}
+	}
+	return backoff
+}
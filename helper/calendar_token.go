@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCalendarToken = errors.New("invalid or revoked calendar feed token")
+
+// SignCalendarToken membuat token feed kalender berisi ID feed. Token tidak
+// kedaluwarsa karena klien kalender terus menarik URL yang sama; feed
+// dihentikan dengan me-revoke-nya.
+func SignCalendarToken(secret []byte, feedId uuid.UUID) string {
+	return signToken(TokenPurposeCalendarFeed, secret, feedId[:])
+}
+
+// ParseCalendarToken memverifikasi tanda tangan token dan mengembalikan ID feed.
+// Status revoke dicek oleh pemanggil.
+func ParseCalendarToken(secret []byte, token string) (uuid.UUID, error) {
+	payload, ok := parseSignedToken(TokenPurposeCalendarFeed, secret, token, 16)
+	if !ok {
+		return uuid.Nil, ErrInvalidCalendarToken
+	}
+	return uuid.UUID(payload), nil
+}
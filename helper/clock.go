@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"context"
+	"time"
+)
+
+const locationContextKey contextKey = "location"
+
+// Now mengembalikan waktu sekarang dalam UTC. Semua waktu yang disimpan
+// memakai fungsi ini agar tidak bergantung pada zona waktu server.
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// ContextWithLocation menyimpan zona waktu user yang sedang login ke dalam context
+func ContextWithLocation(ctx context.Context, location *time.Location) context.Context {
+	return context.WithValue(ctx, locationContextKey, location)
+}
+
+// LocationFromContext mengambil zona waktu user dari context, default UTC
+func LocationFromContext(ctx context.Context) *time.Location {
+	if location, ok := ctx.Value(locationContextKey).(*time.Location); ok && location != nil {
+		return location
+	}
+	return time.UTC
+}
+
+// LoadLocation seperti time.LoadLocation, tapi nama kosong/tidak dikenal menjadi UTC
+func LoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil || name == "" {
+		return time.UTC
+	}
+	return location
+}
+
+// StartOfDay mengembalikan jam 00:00 pada hari kalender t menurut zona waktu location
+func StartOfDay(t time.Time, location *time.Location) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"os"
+	"strconv"
+)
+
+// GetEnvInt membaca environment variable berupa angka, atau fallback jika kosong/tidak valid
+func GetEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetEnv membaca environment variable berupa string, atau fallback jika kosong
+func GetEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// exifOrientationTag adalah tag EXIF Orientation di IFD0
+const exifOrientationTag = 0x0112
+
+// jpegOrientation membaca tag EXIF Orientation (1-8) dari JPEG. Foto dari
+// ponsel biasanya disimpan apa adanya dari sensor dan hanya ditandai
+// orientasinya, jadi gambar harus diputar sebelum dipakai. Mengembalikan 1
+// (normal) jika data bukan JPEG atau tag tidak ditemukan.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		// SOS: setelah ini hanya data gambar, EXIF selalu ada sebelumnya
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation mencari tag Orientation di IFD0 header TIFF milik EXIF
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		// Tipe SHORT (3); nilainya ada di dua byte pertama field value
+		if order.Uint16(tiff[entry+2:]) != 3 {
+			return 1
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+// applyOrientation memutar/membalik gambar sesuai tag EXIF Orientation
+// sehingga hasilnya tegak seperti yang ditampilkan kamera
+func applyOrientation(source image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return source
+	}
+
+	bounds := source.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), source, bounds.Min, draw.Src)
+
+	width, height := bounds.Dx(), bounds.Dy()
+	// Orientasi 5-8 memutar 90 derajat sehingga lebar dan tinggi tertukar
+	targetWidth, targetHeight := width, height
+	if orientation >= 5 {
+		targetWidth, targetHeight = height, width
+	}
+	target := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+
+	for y := 0; y < targetHeight; y++ {
+		for x := 0; x < targetWidth; x++ {
+			// (sx, sy) adalah pixel sumber untuk pixel tujuan (x, y)
+			var sx, sy int
+			switch orientation {
+			case 2: // cermin horizontal
+				sx, sy = width-1-x, y
+			case 3: // putar 180
+				sx, sy = width-1-x, height-1-y
+			case 4: // cermin vertikal
+				sx, sy = x, height-1-y
+			case 5: // transpose
+				sx, sy = y, x
+			case 6: // putar 90 searah jarum jam
+				sx, sy = y, height-1-x
+			case 7: // transverse
+				sx, sy = width-1-y, height-1-x
+			case 8: // putar 90 berlawanan jarum jam
+				sx, sy = width-1-y, x
+			}
+			copy(target.Pix[target.PixOffset(x, y):][:4], rgba.Pix[rgba.PixOffset(sx, sy):][:4])
+		}
+	}
+	return target
+}
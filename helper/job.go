@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunPeriodically menjalankan fn segera lalu setiap interval sampai ctx selesai.
+// Panic di dalam fn dicatat dan tidak menghentikan job berikutnya.
+func RunPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runJob(ctx, name, fn)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runJob(ctx context.Context, name string, fn func(ctx context.Context)) {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Printf("⚠️ Job %s failed: %v\n", name, err)
+		}
+	}()
+	fn(ctx)
+}
@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidShareToken = errors.New("invalid or expired share token")
+
+// SignShareToken membuat token share link berisi ID link dan waktu kedaluwarsa.
+// Token yang sama selalu dihasilkan untuk link yang sama, jadi token tidak
+// perlu disimpan di database.
+func SignShareToken(secret []byte, linkId uuid.UUID, expiresAt time.Time) string {
+	payload := make([]byte, 24)
+	copy(payload, linkId[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+	return signToken(TokenPurposeShareLink, secret, payload)
+}
+
+// ParseShareToken memverifikasi tanda tangan dan masa berlaku token,
+// lalu mengembalikan ID link di dalamnya. Status revoke dicek oleh pemanggil.
+func ParseShareToken(secret []byte, token string, now time.Time) (uuid.UUID, error) {
+	payload, ok := parseSignedToken(TokenPurposeShareLink, secret, token, 24)
+	if !ok {
+		return uuid.Nil, ErrInvalidShareToken
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if !now.Before(expiresAt) {
+		return uuid.Nil, ErrInvalidShareToken
+	}
+
+	linkId, err := uuid.FromBytes(payload[:16])
+	if err != nil {
+		return uuid.Nil, ErrInvalidShareToken
+	}
+	return linkId, nil
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// Tujuan token bertanda tangan. Setiap tujuan memakai kunci turunan sendiri,
+// jadi token satu fitur tidak bisa dipakai di endpoint fitur lain meskipun
+// secret dan panjang payload-nya sama.
+const (
+	TokenPurposeShareLink    = "share-link"
+	TokenPurposeUnsubscribe  = "unsubscribe"
+	TokenPurposeCalendarFeed = "calendar-feed"
+)
+
+// signToken membuat token "payload.tanda-tangan" (base64url) dengan HMAC-SHA256
+// memakai kunci yang diturunkan dari secret dan purpose
+func signToken(purpose string, secret []byte, payload []byte) string {
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(tokenSignature(purpose, secret, payload))
+}
+
+// parseSignedToken memverifikasi token buatan signToken dengan purpose yang sama
+// dan mengembalikan payload-nya jika panjangnya payloadLength
+func parseSignedToken(purpose string, secret []byte, token string, payloadLength int) ([]byte, bool) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, false
+	}
+
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(encoded)
+	if err != nil || len(payload) != payloadLength {
+		return nil, false
+	}
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, tokenSignature(purpose, secret, payload)) {
+		return nil, false
+	}
+	return payload, true
+}
+
+func tokenSignature(purpose string, secret []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, DeriveTokenKey(secret, purpose))
+	mac.Write([]byte(purpose + ":"))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// DeriveTokenKey menurunkan kunci khusus purpose dari secret, sehingga fitur yang
+// berbagi secret (mis. default ke secret JWT) tetap memakai kunci berbeda
+func DeriveTokenKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("task-management token key:" + purpose))
+	return mac.Sum(nil)
+}
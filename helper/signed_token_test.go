@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestShareToken(t *testing.T) {
+	secret := []byte("secret")
+	linkId := uuid.New()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	token := SignShareToken(secret, linkId, now.Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		secret  []byte
+		token   string
+		now     time.Time
+		wantErr bool
+	}{
+		{"valid", secret, token, now, false},
+		{"expired", secret, token, now.Add(time.Hour), true},
+		{"wrong secret", []byte("other"), token, now, true},
+		{"tampered signature", secret, token[:len(token)-2] + "AA", now, true},
+		{"missing separator", secret, "abc", now, true},
+		{"empty", secret, "", now, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseShareToken(test.secret, test.token, test.now)
+			if test.wantErr {
+				if err != ErrInvalidShareToken {
+					t.Fatalf("got %v, %v, want ErrInvalidShareToken", got, err)
+				}
+				return
+			}
+			if err != nil || got != linkId {
+				t.Fatalf("got %v, %v, want %v", got, err, linkId)
+			}
+		})
+	}
+}
+
+func TestSignedTokenRoundTrip(t *testing.T) {
+	secret := []byte("secret")
+	id := uuid.New()
+
+	tests := []struct {
+		name  string
+		sign  func([]byte, uuid.UUID) string
+		parse func([]byte, string) (uuid.UUID, error)
+	}{
+		{"unsubscribe", SignUnsubscribeToken, ParseUnsubscribeToken},
+		{"calendar feed", SignCalendarToken, ParseCalendarToken},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			token := test.sign(secret, id)
+			if got, err := test.parse(secret, token); err != nil || got != id {
+				t.Fatalf("got %v, %v, want %v", got, err, id)
+			}
+			if _, err := test.parse([]byte("other"), token); err == nil {
+				t.Fatal("token accepted with the wrong secret")
+			}
+		})
+	}
+}
+
+// Token dengan payload yang sama tidak boleh berlaku untuk tujuan lain
+func TestSignedTokenPurposeIsolation(t *testing.T) {
+	secret := []byte("secret")
+	id := uuid.New()
+
+	if _, err := ParseCalendarToken(secret, SignUnsubscribeToken(secret, id)); err == nil {
+		t.Error("unsubscribe token accepted as calendar feed token")
+	}
+	if _, err := ParseUnsubscribeToken(secret, SignCalendarToken(secret, id)); err == nil {
+		t.Error("calendar feed token accepted as unsubscribe token")
+	}
+	if string(DeriveTokenKey(secret, TokenPurposeUnsubscribe)) == string(DeriveTokenKey(secret, TokenPurposeCalendarFeed)) {
+		t.Error("purposes share the same derived key")
+	}
+}
@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management/model/web"
+)
+
+// TaskCsvColumns adalah header CSV export task, sama dengan nama field JSON web.TaskResponse
+var TaskCsvColumns = []string{
+	"id", "project_id", "title", "status", "priority", "effort", "difficulty_level",
+	"deliverable", "bottleneck", "continue_tomorrow", "progress", "assignee_id",
+	"labels", "due_date", "created_at", "updated_at",
+}
+
+// TaskCsvLabelSeparator memisahkan label di dalam satu sel CSV
+const TaskCsvLabelSeparator = ","
+
+// ToTaskCsvRecord mengubah task menjadi satu baris CSV sesuai urutan TaskCsvColumns.
+// Waktu ditulis RFC 3339 (UTC), field kosong (nil) ditulis sebagai sel kosong.
+func ToTaskCsvRecord(task web.TaskResponse) []string {
+	assigneeId := ""
+	if task.AssigneeId != nil {
+		assigneeId = task.AssigneeId.String()
+	}
+	dueDate := ""
+	if task.DueDate != nil {
+		dueDate = task.DueDate.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		task.Id.String(),
+		task.ProjectId.String(),
+		EscapeCsvText(task.Title),
+		task.Status,
+		task.Priority,
+		strconv.Itoa(task.Effort),
+		EscapeCsvText(task.DifficultyLevel),
+		EscapeCsvText(task.Deliverable),
+		EscapeCsvText(task.Bottleneck),
+		strconv.FormatBool(task.ContinueTomorrow),
+		EscapeCsvText(task.Progress),
+		assigneeId,
+		EscapeCsvText(strings.Join(task.Labels, TaskCsvLabelSeparator)),
+		dueDate,
+		task.CreatedAt.UTC().Format(time.RFC3339),
+		task.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// EscapeCsvText menambahkan tanda kutip tunggal di depan teks yang diawali
+// karakter formula (=, +, -, @) agar tidak dieksekusi saat file dibuka di
+// spreadsheet (CSV injection). UnescapeCsvText membalikkannya saat import.
+func EscapeCsvText(value string) string {
+	if needsCsvEscape(value) {
+		return "'" + value
+	}
+	return value
+}
+
+func UnescapeCsvText(value string) string {
+	if value != "" && value[0] == '\'' && needsCsvEscape(value[1:]) {
+		return value[1:]
+	}
+	return value
+}
+
+// needsCsvEscape juga berlaku untuk teks yang sudah diawali kutip sebelum
+// karakter formula, supaya teks asli seperti "'=x" tetap utuh setelah
+// export lalu import
+func needsCsvEscape(value string) bool {
+	if value == "" {
+		return false
+	}
+	if value[0] == '\'' {
+		return needsCsvEscape(value[1:])
+	}
+	return strings.ContainsRune("=+-@\t\r", rune(value[0]))
+}
@@ -0,0 +1,87 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"task-management/model/web"
+
+	"github.com/google/uuid"
+)
+
+func TestEscapeCsvText(t *testing.T) {
+	tests := []struct {
+		value   string
+		escaped string
+	}{
+		{"", ""},
+		{"Laporan bulanan", "Laporan bulanan"},
+		{"=SUM(A1:A9)", "'=SUM(A1:A9)"},
+		{"+62 812", "'+62 812"},
+		{"-1", "'-1"},
+		{"@mention", "'@mention"},
+		{"\tindent", "'\tindent"},
+		{"'kutip", "'kutip"},
+		{"'=x", "''=x"},
+		{"''+x", "'''+x"},
+		{"a=b", "a=b"},
+	}
+	for _, test := range tests {
+		escaped := EscapeCsvText(test.value)
+		if escaped != test.escaped {
+			t.Errorf("EscapeCsvText(%q) = %q, want %q", test.value, escaped, test.escaped)
+		}
+		if unescaped := UnescapeCsvText(escaped); unescaped != test.value {
+			t.Errorf("UnescapeCsvText(%q) = %q, want %q", escaped, unescaped, test.value)
+		}
+	}
+}
+
+func TestToTaskCsvRecord(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	assigneeId := uuid.New()
+	dueDate := time.Date(2026, 3, 10, 17, 0, 0, 0, jakarta)
+	task := web.TaskResponse{
+		Id:               uuid.New(),
+		ProjectId:        uuid.New(),
+		Title:            "=HYPERLINK(\"x\")",
+		Status:           "todo",
+		Priority:         "high",
+		Effort:           3,
+		ContinueTomorrow: true,
+		AssigneeId:       &assigneeId,
+		Labels:           []string{"-urgent", "backend"},
+		DueDate:          &dueDate,
+		CreatedAt:        time.Date(2026, 3, 1, 8, 0, 0, 0, jakarta),
+		UpdatedAt:        time.Date(2026, 3, 2, 8, 0, 0, 0, jakarta),
+	}
+
+	record := ToTaskCsvRecord(task)
+	if len(record) != len(TaskCsvColumns) {
+		t.Fatalf("record has %d cells, want %d", len(record), len(TaskCsvColumns))
+	}
+
+	want := map[string]string{
+		"title":             "'=HYPERLINK(\"x\")",
+		"effort":            "3",
+		"continue_tomorrow": "true",
+		"assignee_id":       assigneeId.String(),
+		"labels":            "'-urgent,backend",
+		"due_date":          "2026-03-10T10:00:00Z",
+		"created_at":        "2026-03-01T01:00:00Z",
+		"deliverable":       "",
+	}
+	for i, column := range TaskCsvColumns {
+		if expected, ok := want[column]; ok && record[i] != expected {
+			t.Errorf("%s = %q, want %q", column, record[i], expected)
+		}
+	}
+
+	task.AssigneeId = nil
+	task.DueDate = nil
+	record = ToTaskCsvRecord(task)
+	if cells := strings.Join([]string{record[11], record[13]}, ""); cells != "" {
+		t.Errorf("nil assignee and due date should be empty cells, got %q", cells)
+	}
+}
@@ -18,6 +18,9 @@ func ToTaskResponse(task domain.Task) web.TaskResponse {
 		Bottleneck:     task.Bottleneck,
 		ContinueTomorrow: task.ContinueTomorrow,
 		Progress:       task.Progress,
+		AssigneeId:     task.AssigneeId,
+		Labels:         task.Labels,
+		DueDate:        task.DueDate,
 		CreatedAt:      task.CreatedAt,
 		UpdatedAt:      task.UpdatedAt,
 	}
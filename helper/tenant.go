@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// TenantRole adalah role database tanpa hak BYPASSRLS yang dipakai setiap
+// transaksi aplikasi, sehingga policy row-level security tetap berlaku
+// walaupun koneksi memakai user pemilik tabel.
+const TenantRole = "task_app"
+
+const (
+	workspaceIdContextKey contextKey = "workspace_id"
+	systemContextKey      contextKey = "system"
+)
+
+// ContextWithWorkspaceId menyimpan ID workspace (tenant) user yang sedang login ke dalam context
+func ContextWithWorkspaceId(ctx context.Context, workspaceId uuid.UUID) context.Context {
+	return context.WithValue(ctx, workspaceIdContextKey, workspaceId)
+}
+
+// WorkspaceIdFromContext mengambil ID workspace dari context
+func WorkspaceIdFromContext(ctx context.Context) (uuid.UUID, bool) {
+	workspaceId, ok := ctx.Value(workspaceIdContextKey).(uuid.UUID)
+	return workspaceId, ok && workspaceId != uuid.Nil
+}
+
+// ContextWithSystem menandai context milik proses sistem (job terjadwal, login)
+// yang boleh membaca data lintas workspace.
+func ContextWithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemContextKey, true)
+}
+
+func isSystemContext(ctx context.Context) bool {
+	system, _ := ctx.Value(systemContextKey).(bool)
+	return system
+}
+
+// BeginTx memulai transaksi dengan tenant dari context. Setting berlaku lokal
+// untuk transaksi ini saja, jadi aman dipakai bersama connection pool.
+// Context tanpa workspace dan bukan context sistem tidak akan melihat data apa pun.
+func BeginTx(ctx context.Context, db *sql.DB) (*sql.Tx, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId := ""
+	if id, ok := WorkspaceIdFromContext(ctx); ok {
+		workspaceId = id.String()
+	}
+	bypass := "off"
+	if isSystemContext(ctx) {
+		bypass = "on"
+	}
+
+	_, err = tx.ExecContext(ctx, "SET LOCAL ROLE "+TenantRole)
+	if err == nil {
+		_, err = tx.ExecContext(ctx,
+			"SELECT set_config('app.workspace_id', $1, true), set_config('app.bypass_rls', $2, true)",
+			workspaceId, bypass)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return tx, nil
+}
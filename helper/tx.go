@@ -1,6 +1,9 @@
 package helper
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 func CommitOrRollback(tx *sql.Tx) {
 	err := recover()
@@ -13,3 +16,22 @@ func CommitOrRollback(tx *sql.Tx) {
 		PanicIfError(errorCommit)
 	}
 }
+
+// WithSavepoint menjalankan fn di dalam savepoint pada transaksi tx.
+// Jika fn gagal, hanya perubahan yang dibuat fn yang dibatalkan,
+// transaksi utama tetap bisa dilanjutkan.
+func WithSavepoint(ctx context.Context, tx *sql.Tx, name string, fn func() error) error {
+	_, err := tx.ExecContext(ctx, "SAVEPOINT "+name)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(); err != nil {
+		_, errorRollback := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		PanicIfError(errorRollback)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
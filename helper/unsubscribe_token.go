@@ -0,0 +1,24 @@
+package helper
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// SignUnsubscribeToken membuat token link berhenti berlangganan untuk user.
+// Token tidak kedaluwarsa karena link di email lama harus tetap berfungsi.
+func SignUnsubscribeToken(secret []byte, userId uuid.UUID) string {
+	return signToken(TokenPurposeUnsubscribe, secret, userId[:])
+}
+
+// ParseUnsubscribeToken memverifikasi tanda tangan token dan mengembalikan ID user
+func ParseUnsubscribeToken(secret []byte, token string) (uuid.UUID, error) {
+	payload, ok := parseSignedToken(TokenPurposeUnsubscribe, secret, token, 16)
+	if !ok {
+		return uuid.Nil, ErrInvalidUnsubscribeToken
+	}
+	return uuid.UUID(payload), nil
+}
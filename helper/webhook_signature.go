@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// NewWebhookSecret membuat secret acak untuk menandatangani payload webhook
+func NewWebhookSecret() string {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	PanicIfError(err)
+	return "whsec_" + hex.EncodeToString(secret)
+}
+
+// SignWebhookPayload menghasilkan nilai header X-Webhook-Signature:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// Timestamp ikut ditandatangani agar penerima bisa menolak replay lama.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,135 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets adalah panjang maksimal satu baris konten (RFC 5545 3.1),
+// baris yang lebih panjang dilipat ke baris lanjutan yang diawali spasi
+const maxLineOctets = 75
+
+// Calendar adalah satu objek VCALENDAR (RFC 5545)
+type Calendar struct {
+	ProdId string
+	// Name dan Timezone ditulis sebagai X-WR-CALNAME dan X-WR-TIMEZONE,
+	// dipakai klien kalender sebagai nama dan zona waktu tampilan
+	Name       string
+	Timezone   string
+	Components []*Component
+}
+
+// Component adalah komponen kalender seperti VEVENT atau VTODO
+type Component struct {
+	Name       string
+	properties []string
+}
+
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add menambahkan property dengan value apa adanya (tanpa escape)
+func (c *Component) Add(name string, value string) {
+	c.properties = append(c.properties, name+":"+value)
+}
+
+// AddText menambahkan property bertipe TEXT; karakter khusus di-escape
+func (c *Component) AddText(name string, value string) {
+	c.Add(name, EscapeText(value))
+}
+
+// AddTextList menambahkan property TEXT berisi beberapa value, mis. CATEGORIES
+func (c *Component) AddTextList(name string, values []string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = EscapeText(value)
+	}
+	c.Add(name, strings.Join(escaped, ","))
+}
+
+// AddDateTime menambahkan property DATE-TIME dalam UTC, sehingga tidak perlu VTIMEZONE
+func (c *Component) AddDateTime(name string, t time.Time) {
+	c.Add(name, t.UTC().Format("20060102T150405Z"))
+}
+
+// AddDate menambahkan property DATE (sepanjang hari) dari tanggal t di zona waktunya sendiri
+func (c *Component) AddDate(name string, t time.Time) {
+	c.properties = append(c.properties, name+";VALUE=DATE:"+t.Format("20060102"))
+}
+
+// EscapeText meng-escape value TEXT sesuai RFC 5545 3.3.11
+func EscapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// WriteTo menulis kalender dengan akhir baris CRLF dan baris yang sudah dilipat
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	writer := &lineWriter{writer: bufio.NewWriter(w)}
+	writer.line("BEGIN:VCALENDAR")
+	writer.line("VERSION:2.0")
+	writer.line("PRODID:" + c.ProdId)
+	writer.line("CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		writer.line("X-WR-CALNAME:" + EscapeText(c.Name))
+	}
+	if c.Timezone != "" {
+		writer.line("X-WR-TIMEZONE:" + EscapeText(c.Timezone))
+	}
+	for _, component := range c.Components {
+		writer.line("BEGIN:" + component.Name)
+		for _, property := range component.properties {
+			writer.line(property)
+		}
+		writer.line("END:" + component.Name)
+	}
+	writer.line("END:VCALENDAR")
+
+	if writer.err == nil {
+		writer.err = writer.writer.Flush()
+	}
+	return writer.written, writer.err
+}
+
+type lineWriter struct {
+	writer  *bufio.Writer
+	written int64
+	err     error
+}
+
+// line menulis satu baris konten; pelipatan tidak pernah memotong karakter UTF-8
+func (w *lineWriter) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.write(content[:cut] + "\r\n ")
+		content = content[cut:]
+		// Spasi di awal baris lanjutan ikut dihitung
+		limit = maxLineOctets - 1
+	}
+	w.write(content + "\r\n")
+}
+
+func (w *lineWriter) write(s string) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.writer.WriteString(s)
+	w.written += int64(n)
+	w.err = err
+}
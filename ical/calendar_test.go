@@ -0,0 +1,90 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{"a,b;c", `a\,b\;c`},
+		{`back\slash`, `back\\slash`},
+		{"line1\nline2", `line1\nline2`},
+		{"line1\r\nline2\rline3", `line1\nline2\nline3`},
+	}
+	for _, test := range tests {
+		if got := EscapeText(test.value); got != test.want {
+			t.Errorf("EscapeText(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestWriteToFoldsLongLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+	}{
+		{"short", "Rapat mingguan"},
+		{"exactly one line", strings.Repeat("a", maxLineOctets-len("SUMMARY:"))},
+		{"ascii", strings.Repeat("abcdefghij", 20)},
+		{"multibyte", strings.Repeat("tugas ✓ selesai é ", 12)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := NewComponent("VEVENT")
+			event.AddText("SUMMARY", test.summary)
+			calendar := &Calendar{ProdId: "-//test//EN", Components: []*Component{event}}
+
+			var buffer bytes.Buffer
+			n, err := calendar.WriteTo(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(buffer.Len()) {
+				t.Fatalf("WriteTo returned %d, wrote %d bytes", n, buffer.Len())
+			}
+
+			output := buffer.String()
+			if !strings.HasSuffix(output, "END:VCALENDAR\r\n") {
+				t.Fatalf("output does not end with END:VCALENDAR CRLF: %q", output)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+				if len(line) > maxLineOctets {
+					t.Errorf("line is %d octets: %q", len(line), line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line splits a UTF-8 character: %q", line)
+				}
+			}
+
+			// Membuka lipatan (CRLF + spasi) harus mengembalikan property aslinya
+			unfolded := strings.ReplaceAll(output, "\r\n ", "")
+			if !strings.Contains(unfolded, "\r\nSUMMARY:"+EscapeText(test.summary)+"\r\n") {
+				t.Errorf("unfolded output does not contain the summary: %q", unfolded)
+			}
+		})
+	}
+}
+
+func TestComponentDates(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	event := NewComponent("VTODO")
+	event.AddDateTime("DUE", time.Date(2026, 3, 10, 8, 30, 0, 0, jakarta))
+	event.AddDate("DTSTART", time.Date(2026, 3, 10, 0, 0, 0, 0, jakarta))
+	event.AddTextList("CATEGORIES", []string{"a,b", "c"})
+
+	want := []string{"DUE:20260310T013000Z", "DTSTART;VALUE=DATE:20260310", `CATEGORIES:a\,b,c`}
+	for i, property := range event.properties {
+		if property != want[i] {
+			t.Errorf("property %d = %q, want %q", i, property, want[i])
+		}
+	}
+}
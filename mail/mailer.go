@@ -0,0 +1,20 @@
+package mail
+
+import "context"
+
+// Message adalah satu email siap kirim dengan bagian HTML dan teks biasa
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	// UnsubscribeUrl diisi ke header List-Unsubscribe (RFC 8058, one-click)
+	UnsubscribeUrl string
+}
+
+// Mailer mengirim email. Implementasi SMTP dipakai di produksi; untuk
+// pengembangan arahkan SMTP_HOST/SMTP_PORT ke SMTP catch-all lokal
+// (mis. MailHog atau Mailpit di localhost:1025).
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
@@ -0,0 +1,121 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// Nama template email; assigned dan mentioned sama dengan jenis notifikasi
+const (
+	TemplateAssigned  = "assigned"
+	TemplateMentioned = "mentioned"
+	TemplateDigest    = "digest"
+)
+
+// NotificationData adalah data template assigned dan mentioned
+type NotificationData struct {
+	RecipientName  string
+	ActorName      string
+	TaskTitle      string
+	ProjectName    string
+	Status         string
+	DueDate        string
+	Excerpt        string
+	UnsubscribeUrl string
+}
+
+// DigestData adalah data template digest harian
+type DigestData struct {
+	RecipientName  string
+	Date           string
+	Items          []DigestItemData
+	UnsubscribeUrl string
+}
+
+type DigestItemData struct {
+	Kind        string
+	TaskTitle   string
+	ProjectName string
+	ActorName   string
+	Excerpt     string
+	Time        string
+}
+
+// UnsubscribePageData adalah data halaman konfirmasi berhenti berlangganan
+type UnsubscribePageData struct {
+	Email string
+	Done  bool
+}
+
+// Templates menyimpan template email yang sudah di-parse. Setiap email punya
+// <nama>.html (isi HTML di dalam layout) dan <nama>.txt (subject dan teks biasa).
+type Templates struct {
+	html        map[string]*htmltemplate.Template
+	text        map[string]*texttemplate.Template
+	unsubscribe *htmltemplate.Template
+}
+
+func LoadTemplates() (*Templates, error) {
+	templates := &Templates{
+		html: map[string]*htmltemplate.Template{},
+		text: map[string]*texttemplate.Template{},
+	}
+	for _, name := range []string{TemplateAssigned, TemplateMentioned, TemplateDigest} {
+		html, err := htmltemplate.ParseFS(templateFiles, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, err
+		}
+		text, err := texttemplate.ParseFS(templateFiles, "templates/"+name+".txt")
+		if err != nil {
+			return nil, err
+		}
+		templates.html[name] = html
+		templates.text[name] = text
+	}
+
+	unsubscribe, err := htmltemplate.ParseFS(templateFiles, "templates/unsubscribe_page.html")
+	if err != nil {
+		return nil, err
+	}
+	templates.unsubscribe = unsubscribe
+	return templates, nil
+}
+
+// Render menghasilkan subject, HTML dan teks email; To diisi pemanggil
+func (t *Templates) Render(name string, data interface{}) (Message, error) {
+	html, ok := t.html[name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+	text := t.text[name]
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := text.ExecuteTemplate(&textBody, "text", data); err != nil {
+		return Message{}, err
+	}
+	if err := html.ExecuteTemplate(&htmlBody, "layout", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		// Subject satu baris; baris baru bisa menyisipkan header lain
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    htmlBody.String(),
+		Text:    textBody.String(),
+	}, nil
+}
+
+func (t *Templates) RenderUnsubscribePage(writer io.Writer, data UnsubscribePageData) error {
+	return t.unsubscribe.Execute(writer, data)
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	_ "github.com/lib/pq"
 
 	"task-management/app"
 	"task-management/controller"
+	"task-management/event"
 	"task-management/helper"
+	"task-management/mail"
 	"task-management/middleware"
 	"task-management/repository"
 	"task-management/service"
@@ -36,36 +41,178 @@ func main() {
 	userRepository := repository.NewUserRepository(db)
 	profileRepository := repository.NewProfileRepository(db)
 	refreshTokenRepository := repository.NewRefreshTokenRepository()
+	workspaceRepository := repository.NewWorkspaceRepository(db)
+
+	// Event bus in-process; profile mengikuti perubahan user lewat event
+	eventBus := event.NewBus()
+	profileSyncService := service.NewProfileSyncService(userRepository, profileRepository, db)
+	profileSyncService.Subscribe(eventBus)
 
 	// JWT secret langsung dari konfigurasi service
 	jwtSecret := []byte("rahasia") // atau ambil dari file konfigurasi/service
 
 	// Buat service (kirim repository + db + jwtSecret)
-	userService := service.NewUserService(userRepository, profileRepository, refreshTokenRepository, db, jwtSecret)
+	userService := service.NewUserService(userRepository, profileRepository, refreshTokenRepository, workspaceRepository, eventBus, db, jwtSecret)
+
+	// Buat workspace service
+	workspaceService := service.NewWorkspaceService(workspaceRepository, db, validate)
 
 	// Buat profile service
-	profileService := service.NewProfileService(profileRepository, db)
+	avatarStorage := repository.NewAvatarStorage(helper.GetEnv("AVATAR_DIR", "uploads/avatars"))
+	profileService := service.NewProfileService(profileRepository, avatarStorage, db, validate)
 
 	// Buat project repository
 	projectRepository := repository.NewProjectRepository(db)
 
-	// Buat project service
-	projectService := service.NewProjectService(projectRepository, db)
-
 	// Buat task repository dengan sql.DB
 	taskRepository := repository.NewTaskRepository(db)
 
+	// Buat project member repository (dipakai untuk cek role di semua service project)
+	projectMemberRepository := repository.NewProjectMemberRepository(db)
+
+	// Buat riwayat status task (dicatat task service, dibaca untuk burndown)
+	taskStatusHistoryRepository := repository.NewTaskStatusHistoryRepository(db)
+
+	// Buat watcher task (pembuat dan assignee otomatis mengikuti task)
+	taskWatcherRepository := repository.NewTaskWatcherRepository(db)
+
+	// Buat project service
+	projectService := service.NewProjectService(projectRepository, taskRepository, projectMemberRepository, taskStatusHistoryRepository, taskWatcherRepository, eventBus, db, validate)
+
 	// Buat task service dengan validator
-	taskService := service.NewTaskService(taskRepository, validate)
+	taskService := service.NewTaskService(taskRepository, projectRepository, projectMemberRepository, taskStatusHistoryRepository, taskWatcherRepository, eventBus, db, validate)
+
+	// Buat project burndown service
+	projectBurndownService := service.NewProjectBurndownService(taskStatusHistoryRepository, projectRepository, taskRepository, projectMemberRepository, db, validate)
+
+	// Buat project member service
+	projectMemberService := service.NewProjectMemberService(projectMemberRepository, projectRepository, userRepository, db, validate)
+
+	// Buat project template repository & service
+	projectTemplateRepository := repository.NewProjectTemplateRepository(db)
+	projectTemplateService := service.NewProjectTemplateService(projectTemplateRepository, projectRepository, taskRepository, projectMemberRepository, taskStatusHistoryRepository, taskWatcherRepository, eventBus, db, validate)
+
+	// Buat project snapshot repository & service
+	projectSnapshotRepository := repository.NewProjectSnapshotRepository(db)
+	projectSnapshotService := service.NewProjectSnapshotService(projectSnapshotRepository, projectRepository, taskRepository, projectMemberRepository, profileRepository, db)
+
+	// Buat project health service
+	projectHealthService := service.NewProjectHealthService(projectRepository, taskRepository, taskStatusHistoryRepository, projectMemberRepository, db)
+
+	// Buat project report service (laporan status PDF)
+	projectReportService := service.NewProjectReportService(projectRepository, taskRepository, taskStatusHistoryRepository, projectMemberRepository, db)
+
+	// Buat trash service; item di trash dihapus permanen setelah TRASH_RETENTION_DAYS (default 30 hari)
+	trashRetention := time.Duration(helper.GetEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+	trashService := service.NewTrashService(projectRepository, taskRepository, projectMemberRepository, taskStatusHistoryRepository, eventBus, db, trashRetention)
+
+	// Buat share link service (token ditandatangani dengan SHARE_LINK_SECRET)
+	projectShareLinkRepository := repository.NewProjectShareLinkRepository(db)
+	shareSecret := []byte(helper.GetEnv("SHARE_LINK_SECRET", string(jwtSecret)))
+	publicBaseUrl := helper.GetEnv("PUBLIC_BASE_URL", "http://localhost:3001")
+	projectShareService := service.NewProjectShareService(projectShareLinkRepository, projectRepository, taskRepository, projectMemberRepository, db, validate, shareSecret, publicBaseUrl)
+
+	// Buat webhook service; delivery diantrekan oleh outbox relay dan dikirim oleh job
+	webhookRepository := repository.NewWebhookRepository(db)
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(db)
+	webhookService := service.NewWebhookService(webhookRepository, webhookDeliveryRepository, projectRepository, projectMemberRepository, db, validate)
+
+	// Buat notification service (inbox in-app dan email); email dikirim lewat SMTP (default catch-all lokal
+	// di localhost:1025, mis. MailHog/Mailpit) dan link unsubscribe ditandatangani UNSUBSCRIBE_SECRET
+	mailer, err := mail.NewSMTPMailer(mail.SMTPConfig{
+		Host:     helper.GetEnv("SMTP_HOST", "localhost"),
+		Port:     helper.GetEnvInt("SMTP_PORT", 1025),
+		Username: helper.GetEnv("SMTP_USERNAME", ""),
+		Password: helper.GetEnv("SMTP_PASSWORD", ""),
+		From:     helper.GetEnv("SMTP_FROM", "Task Management <no-reply@localhost>"),
+	})
+	helper.PanicIfError(err)
+	mailTemplates, err := mail.LoadTemplates()
+	helper.PanicIfError(err)
+	unsubscribeSecret := []byte(helper.GetEnv("UNSUBSCRIBE_SECRET", string(jwtSecret)))
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), repository.NewNotificationPreferenceRepository(db), repository.NewEmailMessageRepository(db), repository.NewEmailDigestItemRepository(db), taskRepository, userRepository, profileRepository, projectRepository, projectMemberRepository, taskWatcherRepository, db, validate, mailer, mailTemplates, unsubscribeSecret, publicBaseUrl)
+
+	// Buat calendar feed service (token feed .ics ditandatangani dengan CALENDAR_FEED_SECRET)
+	calendarFeedSecret := []byte(helper.GetEnv("CALENDAR_FEED_SECRET", string(jwtSecret)))
+	calendarFeedService := service.NewCalendarFeedService(repository.NewCalendarFeedRepository(db), taskRepository, projectRepository, userRepository, profileRepository, db, validate, calendarFeedSecret, publicBaseUrl)
+
+	// Buat outbox: event ditulis di transaksi perubahan, lalu relay meneruskan ke
+	// sink yang dipilih lewat OUTBOX_SINKS (webhook, log, nats; default webhook)
+	var outboxSinks []event.Sink
+	for _, sinkName := range strings.Split(helper.GetEnv("OUTBOX_SINKS", "webhook"), ",") {
+		switch strings.TrimSpace(sinkName) {
+		case "webhook":
+			outboxSinks = append(outboxSinks, webhookService)
+		case "log":
+			logSink, err := event.NewLogSink(helper.GetEnv("OUTBOX_LOG_FILE", "logs/events.log"))
+			helper.PanicIfError(err)
+			outboxSinks = append(outboxSinks, logSink)
+		case "nats":
+			natsSink, err := event.NewNatsSink(helper.GetEnv("NATS_URL", "nats://localhost:4222"), helper.GetEnv("NATS_SUBJECT_PREFIX", "task-management"))
+			helper.PanicIfError(err)
+			outboxSinks = append(outboxSinks, natsSink)
+		}
+	}
+	// Notifikasi, broker stream (SSE) dan hub kolaborasi (WebSocket) selalu aktif
+	// dan menerima event yang sudah ter-commit dari relay
+	broker := event.NewBroker()
+	hub := event.NewHub()
+	outboxSinks = append(outboxSinks, notificationService, broker, hub)
+	outboxService := service.NewOutboxService(repository.NewOutboxRepository(db), projectRepository, db, outboxSinks)
+	outboxService.Subscribe(eventBus)
+
+	// Buat project event service (stream perubahan task)
+	projectEventService := service.NewProjectEventService(projectRepository, projectMemberRepository, broker, db)
+
+	// Buat collaboration service (WebSocket board)
+	collaborationService := service.NewCollaborationService(hub, taskService, projectRepository, projectMemberRepository, db)
 
 	// Buat controller
 	userController := controller.NewUserController(userService)
 	profileController := controller.NewProfileController(profileService)
 	projectController := controller.NewProjectController(projectService)
 	taskController := controller.NewTaskController(taskService)
+	projectTemplateController := controller.NewProjectTemplateController(projectTemplateService)
+	projectSnapshotController := controller.NewProjectSnapshotController(projectSnapshotService)
+	projectMemberController := controller.NewProjectMemberController(projectMemberService)
+	projectBurndownController := controller.NewProjectBurndownController(projectBurndownService)
+	trashController := controller.NewTrashController(trashService)
+	projectHealthController := controller.NewProjectHealthController(projectHealthService)
+	workspaceController := controller.NewWorkspaceController(workspaceService)
+	projectShareController := controller.NewProjectShareController(projectShareService)
+	webhookController := controller.NewWebhookController(webhookService)
+	projectEventController := controller.NewProjectEventController(projectEventService)
+	collaborationController := controller.NewCollaborationController(collaborationService)
+	notificationController := controller.NewNotificationController(notificationService, mailTemplates)
+	calendarFeedController := controller.NewCalendarFeedController(calendarFeedService)
+	projectReportController := controller.NewProjectReportController(projectReportService)
 
 	// Update router initialization
-	router := app.NewRouter(userController, profileController, projectController, taskController)
+	router := app.NewRouter(userController, profileController, projectController, taskController, projectTemplateController, projectSnapshotController, projectMemberController, projectBurndownController, trashController, projectHealthController, workspaceController, projectShareController, webhookController, projectEventController, collaborationController, notificationController, calendarFeedController, projectReportController)
+
+	// Job terjadwal berjalan lintas workspace
+	jobContext := helper.ContextWithSystem(context.Background())
+
+	// Jalankan job snapshot harian (upsert per hari, jadi aman dijalankan tiap jam)
+	go helper.RunPeriodically(jobContext, "project-snapshot", time.Hour, projectSnapshotService.TakeSnapshots)
+
+	// Jalankan job pembersihan trash
+	go helper.RunPeriodically(jobContext, "trash-purge", time.Hour, trashService.Purge)
+
+	// Jalankan outbox relay dan pembersihan event yang sudah terkirim
+	go helper.RunPeriodically(jobContext, "outbox-relay", time.Second, outboxService.Relay)
+	go helper.RunPeriodically(jobContext, "outbox-cleanup", time.Hour, outboxService.Cleanup)
+
+	// Jalankan job pengiriman webhook (retry dengan exponential backoff)
+	go helper.RunPeriodically(jobContext, "webhook-delivery", 10*time.Second, webhookService.DeliverPending)
+
+	// Jalankan job pengiriman email (retry dengan exponential backoff) dan digest harian
+	go helper.RunPeriodically(jobContext, "email-delivery", 30*time.Second, notificationService.DeliverEmails)
+	go helper.RunPeriodically(jobContext, "email-digest", 15*time.Minute, notificationService.SendDigests)
+
+	// Jalankan job notifikasi tenggat dan pembersihan inbox
+	go helper.RunPeriodically(jobContext, "notification-due-soon", 15*time.Minute, notificationService.NotifyDueSoon)
+	go helper.RunPeriodically(jobContext, "notification-prune", time.Hour, notificationService.Prune)
 
 	// Jalankan server dengan middleware CORS
 	server := &http.Server{
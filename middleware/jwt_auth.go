@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"task-management/helper"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -40,7 +42,20 @@ func JWTAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), token)))
+	})
+}
+
+// JWTAuthWithQueryToken sama seperti JWTAuth, tetapi juga menerima token dari
+// query ?access_token=... untuk client yang tidak bisa mengirim header
+// Authorization (mis. EventSource di browser).
+func JWTAuthWithQueryToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accessToken := r.URL.Query().Get("access_token"); accessToken != "" && r.Header.Get("Authorization") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		JWTAuth(next).ServeHTTP(w, r)
 	})
 }
 
@@ -73,6 +88,31 @@ func JWTAuthHttprouter(h httprouter.Handle) httprouter.Handle {
 			return
 		}
 
-		h(w, r, ps)
+		h(w, r.WithContext(contextWithClaims(r.Context(), token)), ps)
+	}
+}
+
+// contextWithClaims menyimpan user_id, workspace_id, timezone dan exp dari token ke context request
+func contextWithClaims(ctx context.Context, token *jwt.Token) context.Context {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ctx
+	}
+	if expiresAt, err := claims.GetExpirationTime(); err == nil && expiresAt != nil {
+		ctx = helper.ContextWithTokenExpiry(ctx, expiresAt.Time)
+	}
+	if workspaceIdString, ok := claims["workspace_id"].(string); ok {
+		if workspaceId, err := uuid.Parse(workspaceIdString); err == nil {
+			ctx = helper.ContextWithWorkspaceId(ctx, workspaceId)
+		}
+	}
+	if timezone, ok := claims["timezone"].(string); ok {
+		ctx = helper.ContextWithLocation(ctx, helper.LoadLocation(timezone))
+	}
+	userIdString, _ := claims["user_id"].(string)
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return ctx
 	}
+	return helper.ContextWithUserId(ctx, userId)
 }
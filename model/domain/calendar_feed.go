@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Jenis komponen iCalendar yang dipakai feed
+const (
+	CalendarComponentEvent = "vevent"
+	CalendarComponentTodo  = "vtodo"
+)
+
+// Cakupan task yang masuk ke feed
+const (
+	CalendarScopeAssigned = "assigned"
+	CalendarScopeWatching = "watching"
+	CalendarScopeAll      = "all"
+)
+
+// CalendarFeed adalah feed .ics pribadi milik user. Token-nya tidak disimpan,
+// cukup ditandatangani ulang dari Id; feed yang di-revoke tidak bisa dibuka lagi.
+type CalendarFeed struct {
+	Id        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserId    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Component string     `gorm:"type:text;not null;default:'vevent'"`
+	Scope     string     `gorm:"type:text;not null;default:'assigned'"`
+	RevokedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EmailPending = "pending"
+	EmailSent    = "sent"
+	EmailFailed  = "failed"
+)
+
+// EmailMessage adalah email yang sudah di-render dan menunggu dikirim.
+// Tabel ini sekaligus antrean retry: email gagal tetap pending dengan
+// NextAttemptAt mundur sampai batas percobaan habis.
+type EmailMessage struct {
+	Id        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserId    uuid.UUID `gorm:"type:uuid;not null;index"`
+	ToAddress string    `gorm:"type:text;not null"`
+	Subject   string    `gorm:"type:text;not null"`
+	HtmlBody  string    `gorm:"type:text;not null"`
+	TextBody  string    `gorm:"type:text;not null"`
+	// UnsubscribeUrl dikirim sebagai header List-Unsubscribe
+	UnsubscribeUrl string     `gorm:"type:text;not null;default:''"`
+	Status         string     `gorm:"type:text;not null;default:'pending';index:idx_email_messages_due,priority:1"`
+	Attempts       int        `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time  `gorm:"type:timestamptz;not null;index:idx_email_messages_due,priority:2"`
+	LastAttemptAt  *time.Time `gorm:"type:timestamptz"`
+	LastError      string     `gorm:"type:text;not null;default:''"`
+	SentAt         *time.Time `gorm:"type:timestamptz"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
+
+// EmailDigestItem adalah notifikasi untuk user dengan mode daily yang
+// menunggu digabung ke digest berikutnya.
+type EmailDigestItem struct {
+	Id          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserId      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Kind        string     `gorm:"type:text;not null"`
+	TaskId      uuid.UUID  `gorm:"type:uuid;not null"`
+	TaskTitle   string     `gorm:"type:text;not null"`
+	ProjectId   uuid.UUID  `gorm:"type:uuid;not null"`
+	ProjectName string     `gorm:"type:text;not null"`
+	ActorName   string     `gorm:"type:text;not null;default:''"`
+	Excerpt     string     `gorm:"type:text;not null;default:''"`
+	DigestedAt  *time.Time `gorm:"type:timestamptz;index"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
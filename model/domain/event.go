@@ -0,0 +1,83 @@
+package domain
+
+// Event adalah domain event yang dipublikasikan lewat event bus in-process.
+// Handler dijalankan di dalam transaksi yang sama dengan perubahan asalnya.
+type Event interface {
+	EventName() string
+}
+
+const (
+	EventUserRegistered = "user.registered"
+	EventUserUpdated    = "user.updated"
+
+	EventTaskCreated          = "task.created"
+	EventTaskUpdated          = "task.updated"
+	EventTaskStatusChanged    = "task.status_changed"
+	EventTaskDeleted          = "task.deleted"
+	EventProjectCreated       = "project.created"
+	EventProjectUpdated       = "project.updated"
+	EventProjectStatusChanged = "project.status_changed"
+	EventProjectDeleted       = "project.deleted"
+)
+
+// ProjectEventNames adalah event task/project yang bisa dilanggan dari luar (webhook)
+var ProjectEventNames = []string{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskStatusChanged,
+	EventTaskDeleted,
+	EventProjectCreated,
+	EventProjectUpdated,
+	EventProjectStatusChanged,
+	EventProjectDeleted,
+}
+
+// UserRegistered dipublikasikan setelah user baru tersimpan
+type UserRegistered struct {
+	User User
+}
+
+func (e UserRegistered) EventName() string { return EventUserRegistered }
+
+// UserUpdated dipublikasikan setelah data user (nama, email, role, password) berubah
+type UserUpdated struct {
+	User User
+}
+
+func (e UserUpdated) EventName() string { return EventUserUpdated }
+
+// TaskEvent dipublikasikan untuk perubahan task. Before nil untuk task baru,
+// After nil untuk task yang dihapus.
+type TaskEvent struct {
+	Name   string
+	Before *Task
+	After  *Task
+}
+
+func (e TaskEvent) EventName() string { return e.Name }
+
+// Current mengembalikan kondisi task terakhir (sebelum dihapus untuk task.deleted)
+func (e TaskEvent) Current() Task {
+	if e.After != nil {
+		return *e.After
+	}
+	return *e.Before
+}
+
+// ProjectEvent dipublikasikan untuk perubahan project. Before nil untuk project
+// baru, After nil untuk project yang dihapus.
+type ProjectEvent struct {
+	Name   string
+	Before *Project
+	After  *Project
+}
+
+func (e ProjectEvent) EventName() string { return e.Name }
+
+// Current mengembalikan kondisi project terakhir (sebelum dihapus untuk project.deleted)
+func (e ProjectEvent) Current() Project {
+	if e.After != nil {
+		return *e.After
+	}
+	return *e.Before
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Jenis notifikasi untuk user
+const (
+	NotificationAssigned      = "assigned"
+	NotificationMentioned     = "mentioned"
+	NotificationStatusChanged = "status_changed"
+	NotificationTaskUpdated   = "task_updated"
+	NotificationDueSoon       = "due_soon"
+)
+
+// Mode pengiriman email notifikasi
+const (
+	EmailModeInstant = "instant"
+	EmailModeDaily   = "daily"
+	EmailModeOff     = "off"
+)
+
+const DefaultDigestHour = 8
+
+// NotificationPreference adalah pengaturan email notifikasi per user. User
+// tanpa baris di tabel ini memakai default: instant, digest jam 08:00.
+type NotificationPreference struct {
+	UserId    uuid.UUID `gorm:"type:uuid;primary_key"`
+	EmailMode string    `gorm:"type:text;not null;default:'instant'"`
+	// DigestHour adalah jam (0-23) pengiriman digest harian di zona waktu profile user
+	DigestHour int `gorm:"not null;default:8"`
+	// LastDigestAt dipakai agar digest hanya terkirim sekali per hari
+	LastDigestAt *time.Time `gorm:"type:timestamptz"`
+	UpdatedAt    time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
+
+// Notification adalah satu item di inbox in-app user
+type Notification struct {
+	Id        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserId    uuid.UUID  `gorm:"type:uuid;not null;index:idx_notifications_user,priority:1;uniqueIndex:idx_notifications_dedupe,priority:1"`
+	Kind      string     `gorm:"type:text;not null"`
+	ProjectId *uuid.UUID `gorm:"type:uuid"`
+	TaskId    *uuid.UUID `gorm:"type:uuid"`
+	// ActorId nil untuk notifikasi dari job sistem (mis. due_soon)
+	ActorId *uuid.UUID `gorm:"type:uuid"`
+	Title   string     `gorm:"type:text;not null"`
+	Message string     `gorm:"type:text;not null;default:''"`
+	// DedupeKey mencegah notifikasi yang sama dibuat dua kali (mis. due_soon per tenggat)
+	DedupeKey *string    `gorm:"type:text;uniqueIndex:idx_notifications_dedupe,priority:2"`
+	ReadAt    *time.Time `gorm:"type:timestamptz"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP;index:idx_notifications_user,priority:2"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AggregateTask    = "task"
+	AggregateProject = "project"
+	AggregateUser    = "user"
+)
+
+// OutboxEvent adalah event yang ditulis di transaksi yang sama dengan perubahan
+// datanya, lalu diteruskan relay ke sink (webhook, NATS, file log). Sequence
+// menentukan urutan; event satu aggregate selalu dikirim berurutan.
+type OutboxEvent struct {
+	Id            uuid.UUID `gorm:"type:uuid;primary_key"`
+	Sequence      int64     `gorm:"type:bigserial;not null;uniqueIndex"`
+	AggregateType string    `gorm:"type:text;not null;index:idx_outbox_events_aggregate,priority:1"`
+	AggregateId   uuid.UUID `gorm:"type:uuid;not null;index:idx_outbox_events_aggregate,priority:2"`
+	EventType     string    `gorm:"type:text;not null"`
+	// WorkspaceId nil untuk event yang tidak terikat workspace
+	WorkspaceId *uuid.UUID `gorm:"type:uuid"`
+	// Payload adalah web.EventMessage dalam bentuk JSON
+	Payload       string     `gorm:"type:jsonb;not null"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"type:timestamptz;not null;index"`
+	LastError     string     `gorm:"type:text;not null;default:''"`
+	PublishedAt   *time.Time `gorm:"type:timestamptz;index"`
+	CreatedAt     time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
@@ -1,6 +1,15 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultProfileTimezone = "Asia/Jakarta"
+	DefaultProfileLocale   = "id-ID"
+)
 
 type Profile struct {
 	Id       uuid.UUID
@@ -8,4 +17,15 @@ type Profile struct {
 	FullName string
 	Email    string
 	Role     string
-}
\ No newline at end of file
+	JobTitle string `gorm:"type:text;not null;default:''"`
+	// Timezone nama zona IANA, mis. "Asia/Jakarta"
+	Timezone string `gorm:"type:text;not null;default:'Asia/Jakarta'"`
+	// Locale tag BCP 47, mis. "id-ID"
+	Locale string `gorm:"type:text;not null;default:'id-ID'"`
+	// WorkingHoursStart dan WorkingHoursEnd format "15:04" di Timezone user
+	WorkingHoursStart string `gorm:"type:text;not null;default:''"`
+	WorkingHoursEnd   string `gorm:"type:text;not null;default:''"`
+	Bio               string `gorm:"type:text;not null;default:''"`
+	// AvatarUpdatedAt nil jika user belum upload avatar; dipakai juga sebagai versi URL avatar
+	AvatarUpdatedAt *time.Time `gorm:"type:timestamptz"`
+}
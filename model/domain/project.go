@@ -5,14 +5,39 @@ import (
 	"time"
 )
 
+const (
+	ProgressModeAuto   = "auto"
+	ProgressModeManual = "manual"
+)
+
+// Status lifecycle project
+const (
+	ProjectStatusPlanned   = "planned"
+	ProjectStatusActive    = "active"
+	ProjectStatusOnHold    = "on-hold"
+	ProjectStatusCompleted = "completed"
+	ProjectStatusArchived  = "archived"
+)
+
 type Project struct {
 	Id          uuid.UUID
 	Name        string
 	Description string
 	Progress    float64
-	Confidence  float64
-	Trend       string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	UserId      uuid.UUID
-}
\ No newline at end of file
+	// ProgressMode "auto" (dihitung dari task) atau "manual" (diisi user)
+	ProgressMode string `gorm:"type:text;default:'auto'"`
+	Confidence   float64
+	Trend        string
+	// Status lifecycle project; project archived bersifat read-only
+	Status string `gorm:"type:text;default:'active'"`
+	// ArchivedFromStatus adalah status sebelum diarsipkan, dipakai saat unarchive
+	ArchivedFromStatus string `gorm:"type:text"`
+	StartDate          *time.Time
+	DueDate            *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	UserId             uuid.UUID
+	WorkspaceId        uuid.UUID `gorm:"type:uuid;index"`
+	// DeletedAt terisi jika project ada di trash (soft delete)
+	DeletedAt *time.Time `gorm:"type:timestamptz;index"`
+}
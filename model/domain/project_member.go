@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProjectRoleOwner      = "owner"
+	ProjectRoleMaintainer = "maintainer"
+	ProjectRoleMember     = "member"
+	ProjectRoleViewer     = "viewer"
+)
+
+// ProjectMember adalah keanggotaan user di sebuah project beserta role-nya
+type ProjectMember struct {
+	ProjectId uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserId    uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Role      string    `gorm:"type:text;not null;check:role IN ('owner','maintainer','member','viewer')"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+	// Diisi dari tabel users saat membaca daftar anggota
+	FullName string `gorm:"-"`
+	Email    string `gorm:"-"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectShareLink adalah link publik read-only ke sebuah project.
+// Token-nya tidak disimpan, cukup ditandatangani ulang dari Id dan ExpiresAt.
+type ProjectShareLink struct {
+	Id        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectId uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CreatedBy uuid.UUID  `gorm:"type:uuid;not null"`
+	ExpiresAt time.Time  `gorm:"type:timestamptz;not null"`
+	RevokedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectSnapshot adalah rekaman harian kondisi sebuah project,
+// dipakai untuk menghitung Trend dan Confidence.
+type ProjectSnapshot struct {
+	Id              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectId       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_project_snapshots_project_day"`
+	SnapshotDate    time.Time `gorm:"type:date;not null;uniqueIndex:idx_project_snapshots_project_day"`
+	Progress        float64   `gorm:"type:numeric(5,2);not null"`
+	OpenTasks       int       `gorm:"not null"`
+	CompletedEffort int       `gorm:"not null"`
+	TotalEffort     int       `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectTemplate menyimpan struktur project (beserta task-nya) yang bisa
+// dipakai ulang untuk membuat project baru.
+type ProjectTemplate struct {
+	Id              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name            string     `gorm:"type:text;not null"`
+	Description     string     `gorm:"type:text"`
+	SourceProjectId *uuid.UUID `gorm:"type:uuid"`
+	// DurationDays adalah jarak due date project dari tanggal mulainya
+	DurationDays *int `gorm:"type:integer"`
+	// UserId adalah pembuat template; hanya dia yang boleh menghapusnya
+	UserId uuid.UUID `gorm:"type:uuid;not null"`
+	// Shared membuat template terlihat dan bisa dipakai user lain di workspace
+	Shared      bool                  `gorm:"not null;default:false"`
+	WorkspaceId uuid.UUID             `gorm:"type:uuid;index"`
+	CreatedAt   time.Time             `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time             `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+	Tasks       []ProjectTemplateTask `gorm:"-"`
+}
+
+type ProjectTemplateTask struct {
+	Id              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TemplateId      uuid.UUID `gorm:"type:uuid;not null;index"`
+	Position        int       `gorm:"not null"`
+	Title           string    `gorm:"type:text;not null"`
+	Priority        string    `gorm:"type:text;default:'medium'"`
+	Effort          int       `gorm:"not null"`
+	DifficultyLevel string    `gorm:"type:text"`
+	Deliverable     string    `gorm:"type:text"`
+	Labels          []string  `gorm:"type:text[]"`
+	// DueOffsetDays adalah jarak due date task dari tanggal mulai project
+	DueOffsetDays *int `gorm:"type:integer"`
+}
@@ -17,6 +17,6 @@ func NewRefreshToken(userID uuid.UUID, tokenString string) RefreshToken {
         Id:        uuid.New(),
         UserID:    userID,
         Token:     tokenString,
-        ExpiresAt: time.Now().Add(7 * 24 * time.Hour), // 7 hari
+        ExpiresAt: time.Now().UTC().Add(7 * 24 * time.Hour), // 7 hari
     }
 }
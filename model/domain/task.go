@@ -18,6 +18,21 @@ type Task struct {
 	Bottleneck     string    `gorm:"type:text"`
 	Progress       string    `gorm:"type:text"`
 	ContinueTomorrow bool     `gorm:"type:boolean;default:false"`
+	AssigneeId     *uuid.UUID `gorm:"type:uuid"`
+	Labels         []string  `gorm:"type:text[]"`
+	DueDate        *time.Time `gorm:"type:timestamptz"`
 	CreatedAt      time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt      time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-}
\ No newline at end of file
+	// DeletedAt terisi jika task ada di trash (soft delete)
+	DeletedAt *time.Time `gorm:"type:timestamptz;index"`
+}
+
+// TaskFilter dipakai untuk memilih task berdasarkan kriteria tertentu,
+// misalnya pada operasi bulk. Field yang kosong/nil diabaikan.
+type TaskFilter struct {
+	ProjectId  *uuid.UUID
+	Status     *string
+	Priority   *string
+	AssigneeId *uuid.UUID
+	Label      *string
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskStatusDeleted dipakai sebagai ToStatus saat task dihapus atau
+// dipindahkan keluar dari project.
+const TaskStatusDeleted = "deleted"
+
+// TaskStatusHistory mencatat perubahan status (dan effort) sebuah task di
+// dalam project, dipakai untuk merekonstruksi burndown harian.
+// FromStatus kosong berarti task baru masuk ke project.
+type TaskStatusHistory struct {
+	Id         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TaskId     uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProjectId  uuid.UUID `gorm:"type:uuid;not null;index:idx_task_status_histories_project_changed"`
+	FromStatus string    `gorm:"type:text"`
+	ToStatus   string    `gorm:"type:text;not null"`
+	Effort     int       `gorm:"not null"`
+	ChangedAt  time.Time `gorm:"type:timestamptz;not null;index:idx_task_status_histories_project_changed"`
+}
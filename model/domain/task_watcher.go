@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskWatcher adalah user yang mengikuti perubahan sebuah task dan menerima
+// notifikasinya di inbox
+type TaskWatcher struct {
+	TaskId    uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserId    uuid.UUID `gorm:"type:uuid;primary_key;index"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
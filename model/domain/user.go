@@ -17,6 +17,7 @@ type User struct {
 	Email        string     `json:"email"`
 	PasswordHash string     `json:"password_hash"`
 	Role         string     `json:"role"`
+	WorkspaceId  uuid.UUID  `json:"workspace_id" gorm:"type:uuid;index"`
 	CreatedAt    *time.Time `json:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at"`
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook adalah langganan event ke URL luar. ProjectId nil berarti
+// berlaku untuk semua project di workspace; Events kosong berarti semua event.
+type Webhook struct {
+	Id          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WorkspaceId uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ProjectId   *uuid.UUID `gorm:"type:uuid;index"`
+	Url         string     `gorm:"type:text;not null"`
+	// Secret dipakai menandatangani payload (HMAC-SHA256)
+	Secret    string    `gorm:"type:text;not null"`
+	Events    []string  `gorm:"type:text[];not null;default:'{}'"`
+	Active    bool      `gorm:"not null;default:true"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
+
+// WebhookDelivery adalah satu pengiriman event ke webhook, sekaligus antrean
+// (status pending + NextAttemptAt) dan log hasil pengiriman terakhir.
+type WebhookDelivery struct {
+	Id            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WebhookId     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EventId       uuid.UUID  `gorm:"type:uuid;not null"`
+	EventType     string     `gorm:"type:text;not null"`
+	Payload       string     `gorm:"type:jsonb;not null"`
+	Status        string     `gorm:"type:text;not null;default:'pending';index:idx_webhook_deliveries_due,priority:1"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"type:timestamptz;not null;index:idx_webhook_deliveries_due,priority:2"`
+	LastAttemptAt *time.Time `gorm:"type:timestamptz"`
+	// LastStatusCode nil jika request gagal sebelum mendapat response
+	LastStatusCode *int       `gorm:"type:integer"`
+	LastError      string     `gorm:"type:text;not null;default:''"`
+	LastResponse   string     `gorm:"type:text;not null;default:''"`
+	DeliveredAt    *time.Time `gorm:"type:timestamptz"`
+	// RedeliveryOf terisi jika delivery ini adalah pengiriman ulang manual
+	RedeliveryOf *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWorkspaceId adalah workspace untuk data yang sudah ada sebelum multi-tenancy
+var DefaultWorkspaceId = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Workspace adalah tenant yang memiliki user dan project. Data antar
+// workspace dipisahkan dengan row-level security di Postgres.
+type Workspace struct {
+	Id        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,26 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CalendarFeedCreateRequest struct {
+	// Component "vevent" (default, tampil sebagai acara) atau "vtodo" (tampil sebagai tugas)
+	Component string `validate:"omitempty,oneof=vevent vtodo" json:"component"`
+	// Scope task yang dimasukkan: "assigned" (default), "watching" atau "all"
+	Scope string `validate:"omitempty,oneof=assigned watching all" json:"scope"`
+}
+
+type CalendarFeedResponse struct {
+	Id        uuid.UUID `json:"id"`
+	Component string    `json:"component"`
+	Scope     string    `json:"scope"`
+	Token     string    `json:"token"`
+	Url       string    `json:"url"`
+	// WebcalUrl adalah Url dengan skema webcal:// untuk langsung berlangganan dari klien kalender
+	WebcalUrl string     `json:"webcal_url"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
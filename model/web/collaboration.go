@@ -0,0 +1,41 @@
+package web
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// CollaborationRequest adalah pesan dari client WebSocket. Type menentukan field
+// yang dipakai: subscribe/unsubscribe (ProjectId), view (ProjectId, TaskId;
+// TaskId kosong berarti hanya melihat board), edit (TaskId, Patch).
+type CollaborationRequest struct {
+	Type string `json:"type"`
+	// Ref diisi client dan dikembalikan di balasan (ack/reject/error)
+	Ref       string             `json:"ref"`
+	ProjectId *uuid.UUID         `json:"project_id"`
+	TaskId    *uuid.UUID         `json:"task_id"`
+	Patch     *TaskUpdateRequest `json:"patch"`
+}
+
+// CollaborationMessage adalah pesan dari server ke client WebSocket
+type CollaborationMessage struct {
+	Type      string             `json:"type"`
+	Ref       string             `json:"ref,omitempty"`
+	ProjectId *uuid.UUID         `json:"project_id,omitempty"`
+	TaskId    *uuid.UUID         `json:"task_id,omitempty"`
+	UserId    *uuid.UUID         `json:"user_id,omitempty"`
+	Task      *TaskResponse      `json:"task,omitempty"`
+	Patch     *TaskUpdateRequest `json:"patch,omitempty"`
+	Presence  []PresenceResponse `json:"presence,omitempty"`
+	// Event dan Data berisi event task yang sudah ter-commit (sama seperti stream SSE)
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// PresenceResponse adalah satu user yang sedang membuka board atau task
+type PresenceResponse struct {
+	UserId uuid.UUID  `json:"user_id"`
+	TaskId *uuid.UUID `json:"task_id"`
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventMessage adalah bentuk event yang dikirim ke luar (webhook, NATS, file log)
+type EventMessage struct {
+	Id            uuid.UUID  `json:"id"`
+	Type          string     `json:"type"`
+	AggregateType string     `json:"aggregate_type"`
+	AggregateId   uuid.UUID  `json:"aggregate_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	WorkspaceId   *uuid.UUID `json:"workspace_id"`
+	ProjectId     *uuid.UUID `json:"project_id,omitempty"`
+	// ActorId adalah user yang melakukan perubahan (kosong untuk job sistem)
+	ActorId *uuid.UUID  `json:"actor_id,omitempty"`
+	Data    interface{} `json:"data"`
+}
+
+type TaskEventData struct {
+	Task TaskResponse `json:"task"`
+	// Previous adalah kondisi task sebelum berubah (untuk event *.updated dan *.status_changed)
+	Previous *TaskResponse `json:"previous,omitempty"`
+}
+
+type ProjectEventData struct {
+	Project  ProjectResponse  `json:"project"`
+	Previous *ProjectResponse `json:"previous,omitempty"`
+}
+
+// UserEventData sengaja tidak menyertakan password
+type UserEventData struct {
+	Id          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	FullName    string     `json:"full_name"`
+	WorkspaceId uuid.UUID  `json:"workspace_id"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
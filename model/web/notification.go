@@ -0,0 +1,57 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationPreferenceUpdateRequest struct {
+	// EmailMode instant (langsung), daily (digest harian) atau off
+	EmailMode string `validate:"required,oneof=instant daily off" json:"email_mode"`
+	// DigestHour nil berarti jam digest tidak diubah
+	DigestHour *int `validate:"omitempty,min=0,max=23" json:"digest_hour"`
+}
+
+type NotificationPreferenceResponse struct {
+	EmailMode  string `json:"email_mode"`
+	DigestHour int    `json:"digest_hour"`
+	// DigestTimezone adalah zona waktu profile yang dipakai untuk DigestHour
+	DigestTimezone string     `json:"digest_timezone"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+type NotificationListRequest struct {
+	UnreadOnly bool
+	// Before diisi next_before dari halaman sebelumnya
+	Before *time.Time
+	Limit  int `validate:"min=0,max=100"`
+}
+
+type NotificationMarkRequest struct {
+	Ids []uuid.UUID `validate:"required,min=1,max=100" json:"ids"`
+}
+
+type NotificationResponse struct {
+	Id        uuid.UUID  `json:"id"`
+	Kind      string     `json:"kind"`
+	ProjectId *uuid.UUID `json:"project_id"`
+	TaskId    *uuid.UUID `json:"task_id"`
+	ActorId   *uuid.UUID `json:"actor_id"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	UnreadCount   int                    `json:"unread_count"`
+	Notifications []NotificationResponse `json:"notifications"`
+	// NextBefore kosong jika tidak ada halaman berikutnya
+	NextBefore *time.Time `json:"next_before"`
+}
+
+type NotificationCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
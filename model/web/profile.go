@@ -1,12 +1,17 @@
 package web
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type ProfileCreateRequest struct {
 	UserId   uuid.UUID `json:"user_id" validate:"required"`
 	FullName string    `json:"full_name" validate:"required"`
 	Email    string    `json:"email" validate:"required,email"`
 	Role     string    `json:"role" validate:"required,oneof=SE SCE"`
+	ProfileDetails
 }
 
 type ProfileUpdateRequest struct {
@@ -15,6 +20,18 @@ type ProfileUpdateRequest struct {
 	FullName string    `json:"full_name" validate:"required"`
 	Email    string    `json:"email" validate:"required,email"`
 	Role     string    `json:"role" validate:"required,oneof=SE SCE"`
+	ProfileDetails
+}
+
+// ProfileDetails adalah data profil tambahan yang bisa diubah user sendiri.
+// Timezone dan Locale kosong berarti memakai nilai sebelumnya (atau default).
+type ProfileDetails struct {
+	JobTitle          string `json:"job_title" validate:"max=100"`
+	Timezone          string `json:"timezone" validate:"omitempty,timezone"`
+	Locale            string `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	WorkingHoursStart string `json:"working_hours_start" validate:"required_with=WorkingHoursEnd,omitempty,datetime=15:04"`
+	WorkingHoursEnd   string `json:"working_hours_end" validate:"required_with=WorkingHoursStart,omitempty,datetime=15:04"`
+	Bio               string `json:"bio" validate:"max=1000"`
 }
 
 type ProfileResponse struct {
@@ -23,4 +40,8 @@ type ProfileResponse struct {
 	FullName string    `json:"full_name"`
 	Email    string    `json:"email"`
 	Role     string    `json:"role"`
-}
\ No newline at end of file
+	ProfileDetails
+	// Avatars berisi URL thumbnail per ukuran (pixel); kosong jika belum ada avatar
+	Avatars         map[int]string `json:"avatars,omitempty"`
+	AvatarUpdatedAt *time.Time     `json:"avatar_updated_at"`
+}
@@ -0,0 +1,13 @@
+package web
+
+import "github.com/google/uuid"
+
+// ProfileReconcileResult adalah hasil pencocokan ulang profiles terhadap users
+type ProfileReconcileResult struct {
+	Checked int `json:"checked"`
+	// Created adalah user yang belum punya profile
+	Created []uuid.UUID `json:"created"`
+	// Updated adalah user yang profile-nya berbeda (nama, email atau role)
+	Updated []uuid.UUID `json:"updated"`
+	DryRun  bool        `json:"dry_run"`
+}
@@ -9,9 +9,16 @@ type ProjectCreateRequest struct {
 	Name        string  `validate:"required" json:"name"`
 	Description string  `json:"description"`
 	Progress    float64 `validate:"min=0,max=100" json:"progress"`
-	Confidence  float64 `validate:"min=0,max=100" json:"confidence"`
-	Trend       string  `validate:"oneof=up down stable" json:"trend"`
-	UserId      uuid.UUID `validate:"required" json:"user_id"`
+	// ProgressMode "auto" (default, dihitung dari task) atau "manual" (pakai Progress)
+	ProgressMode string `validate:"omitempty,oneof=auto manual" json:"progress_mode"`
+	// Confidence dan Trend dihitung dari snapshot harian; request yang masih
+	// mengirimnya ditolak agar klien tahu nilainya tidak dipakai
+	Confidence *float64 `json:"confidence,omitempty" swaggerignore:"true"`
+	Trend      *string  `json:"trend,omitempty" swaggerignore:"true"`
+	// Status awal project, default "active"
+	Status    string     `validate:"omitempty,oneof=planned active on-hold completed" json:"status"`
+	StartDate *time.Time `json:"start_date"`
+	DueDate   *time.Time `json:"due_date"`
 }
 
 type ProjectUpdateRequest struct {
@@ -19,18 +26,55 @@ type ProjectUpdateRequest struct {
 	Name        string    `validate:"required" json:"name"`
 	Description string    `json:"description"`
 	Progress    float64   `validate:"min=0,max=100" json:"progress"`
-	Confidence  float64   `validate:"min=0,max=100" json:"confidence"`
-	Trend       string    `validate:"oneof=up down stable" json:"trend"`
+	// ProgressMode kosong berarti mode tidak diubah; Progress hanya dipakai pada mode manual
+	ProgressMode string     `validate:"omitempty,oneof=auto manual" json:"progress_mode"`
+	StartDate    *time.Time `json:"start_date"`
+	DueDate      *time.Time `json:"due_date"`
+	// Confidence dan Trend dihitung dari snapshot harian; request yang masih
+	// mengirimnya ditolak agar klien tahu nilainya tidak dipakai
+	Confidence *float64 `json:"confidence,omitempty" swaggerignore:"true"`
+	Trend      *string  `json:"trend,omitempty" swaggerignore:"true"`
 }
 
 type ProjectResponse struct {
-	Id          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Progress    float64   `json:"progress"`
-	Confidence  float64   `json:"confidence"`
-	Trend       string    `json:"trend"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	UserId      uuid.UUID `json:"user_id"`
-}
\ No newline at end of file
+	Id           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Progress     float64    `json:"progress"`
+	ProgressMode string     `json:"progress_mode"`
+	Confidence   float64    `json:"confidence"`
+	Trend        string     `json:"trend"`
+	Status       string     `json:"status"`
+	StartDate    *time.Time `json:"start_date"`
+	DueDate      *time.Time `json:"due_date"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	UserId       uuid.UUID  `json:"user_id"`
+}
+
+// ProjectStatusRequest memindahkan project ke status lifecycle lain
+type ProjectStatusRequest struct {
+	Id     uuid.UUID `json:"-"`
+	Status string    `validate:"required,oneof=planned active on-hold completed archived" json:"status"`
+}
+
+// ProjectCloneRequest menyalin project beserta seluruh task-nya
+type ProjectCloneRequest struct {
+	Id   uuid.UUID `json:"-"`
+	Name string    `json:"name"`
+	// UserId pemilik project hasil clone; hanya boleh user yang melakukan clone
+	// (field lama, nilai lain ditolak)
+	UserId *uuid.UUID `json:"user_id"`
+	// ResetStatus mengembalikan status semua task ke "todo"
+	ResetStatus bool `json:"reset_status"`
+	// ResetProgress mengosongkan progress project dan task
+	ResetProgress bool `json:"reset_progress"`
+	// ShiftDays menggeser semua tanggal (start, due) sejumlah hari
+	ShiftDays int `json:"shift_days"`
+}
+
+type ProjectCloneResponse struct {
+	Project ProjectResponse `json:"project"`
+	// TaskIdMap memetakan ID task lama ke ID task baru
+	TaskIdMap map[uuid.UUID]uuid.UUID `json:"task_id_map"`
+}
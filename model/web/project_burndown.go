@@ -0,0 +1,33 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectBurndownRequest struct {
+	ProjectId uuid.UUID
+	From      *time.Time
+	To        *time.Time
+	Unit      string `validate:"omitempty,oneof=effort count"`
+}
+
+// ProjectBurndownPoint adalah kondisi project pada akhir sebuah hari.
+// Remaining dipakai untuk burndown, Completed dan Scope untuk burnup.
+type ProjectBurndownPoint struct {
+	Date         string  `json:"date"`
+	Remaining    float64 `json:"remaining"`
+	Completed    float64 `json:"completed"`
+	Scope        float64 `json:"scope"`
+	ScopeChanged bool    `json:"scope_changed"`
+	ScopeChange  float64 `json:"scope_change,omitempty"`
+}
+
+type ProjectBurndownResponse struct {
+	ProjectId uuid.UUID              `json:"project_id"`
+	Unit      string                 `json:"unit"`
+	From      string                 `json:"from"`
+	To        string                 `json:"to"`
+	Points    []ProjectBurndownPoint `json:"points"`
+}
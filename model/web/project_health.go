@@ -0,0 +1,30 @@
+package web
+
+import "github.com/google/uuid"
+
+// ProjectHealthReason menjelaskan satu faktor yang menurunkan skor kesehatan project
+type ProjectHealthReason struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Count   int     `json:"count"`
+	Penalty float64 `json:"penalty"`
+}
+
+type ProjectHealthMetrics struct {
+	OpenTasks         int     `json:"open_tasks"`
+	OverdueTasks      int     `json:"overdue_tasks"`
+	OpenBottlenecks   int     `json:"open_bottlenecks"`
+	StuckTasks        int     `json:"stuck_tasks"`
+	HighPriorityRatio float64 `json:"high_priority_ratio"`
+	CarriedOverTasks  int     `json:"carried_over_tasks"`
+}
+
+type ProjectHealthResponse struct {
+	ProjectId uuid.UUID `json:"project_id"`
+	// Status "green", "amber" atau "red"
+	Status             string                `json:"status"`
+	Score              float64               `json:"score"`
+	StuckThresholdDays int                   `json:"stuck_threshold_days"`
+	Metrics            ProjectHealthMetrics  `json:"metrics"`
+	Reasons            []ProjectHealthReason `json:"reasons"`
+}
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectMemberCreateRequest struct {
+	ProjectId uuid.UUID `json:"-"`
+	UserId    uuid.UUID `json:"user_id" validate:"required"`
+	Role      string    `json:"role" validate:"required,oneof=owner maintainer member viewer"`
+}
+
+type ProjectMemberUpdateRequest struct {
+	ProjectId uuid.UUID `json:"-"`
+	UserId    uuid.UUID `json:"-"`
+	Role      string    `json:"role" validate:"required,oneof=owner maintainer member viewer"`
+}
+
+type ProjectMemberResponse struct {
+	ProjectId uuid.UUID `json:"project_id"`
+	UserId    uuid.UUID `json:"user_id"`
+	FullName  string    `json:"full_name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectShareLinkCreateRequest struct {
+	ProjectId uuid.UUID `json:"-"`
+	// ExpiresInHours masa berlaku link, default 168 (7 hari), maksimal 1 tahun
+	ExpiresInHours int `validate:"omitempty,min=1,max=8760" json:"expires_in_hours"`
+}
+
+type ProjectShareLinkResponse struct {
+	Id        uuid.UUID  `json:"id"`
+	ProjectId uuid.UUID  `json:"project_id"`
+	Token     string     `json:"token"`
+	Url       string     `json:"url"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SharedProjectResponse adalah ringkasan project untuk share link publik.
+// Field internal (pemilik, assignee, bottleneck, dll) sengaja tidak disertakan.
+type SharedProjectResponse struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Status      string               `json:"status"`
+	Progress    float64              `json:"progress"`
+	StartDate   *time.Time           `json:"start_date"`
+	DueDate     *time.Time           `json:"due_date"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+	Tasks       []SharedTaskResponse `json:"tasks"`
+}
+
+type SharedTaskResponse struct {
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	Effort      int        `json:"effort"`
+	Deliverable string     `json:"deliverable"`
+	Progress    string     `json:"progress"`
+	Labels      []string   `json:"labels"`
+	DueDate     *time.Time `json:"due_date"`
+}
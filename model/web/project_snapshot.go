@@ -0,0 +1,17 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectSnapshotResponse struct {
+	ProjectId       uuid.UUID `json:"project_id"`
+	SnapshotDate    string    `json:"snapshot_date"`
+	Progress        float64   `json:"progress"`
+	OpenTasks       int       `json:"open_tasks"`
+	CompletedEffort int       `json:"completed_effort"`
+	TotalEffort     int       `json:"total_effort"`
+	CreatedAt       time.Time `json:"created_at"`
+}
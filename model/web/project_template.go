@@ -0,0 +1,52 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectTemplateCreateRequest menyimpan project yang sudah ada sebagai template
+type ProjectTemplateCreateRequest struct {
+	ProjectId   uuid.UUID `json:"-"`
+	Name        string    `json:"name" validate:"required"`
+	Description string    `json:"description"`
+	// Shared membuat template terlihat oleh user lain di workspace
+	Shared bool `json:"shared"`
+}
+
+// ProjectFromTemplateRequest membuat project baru dari template.
+// Placeholder {{key}} pada nama project dan judul task diganti dengan
+// nilai dari Variables, ditambah {{project_name}} dan {{start_date}}.
+type ProjectFromTemplateRequest struct {
+	TemplateId  uuid.UUID         `json:"-"`
+	Name        string            `json:"name" validate:"required"`
+	Description string            `json:"description"`
+	StartDate   *time.Time        `json:"start_date"`
+	Variables   map[string]string `json:"variables"`
+}
+
+type ProjectTemplateTaskResponse struct {
+	Id              uuid.UUID `json:"id"`
+	Position        int       `json:"position"`
+	Title           string    `json:"title"`
+	Priority        string    `json:"priority"`
+	Effort          int       `json:"effort"`
+	DifficultyLevel string    `json:"difficulty_level"`
+	Deliverable     string    `json:"deliverable"`
+	Labels          []string  `json:"labels"`
+	DueOffsetDays   *int      `json:"due_offset_days"`
+}
+
+type ProjectTemplateResponse struct {
+	Id              uuid.UUID                     `json:"id"`
+	Name            string                        `json:"name"`
+	Description     string                        `json:"description"`
+	SourceProjectId *uuid.UUID                    `json:"source_project_id"`
+	DurationDays    *int                          `json:"duration_days"`
+	UserId          uuid.UUID                     `json:"user_id"`
+	Shared          bool                          `json:"shared"`
+	CreatedAt       time.Time                     `json:"created_at"`
+	UpdatedAt       time.Time                     `json:"updated_at"`
+	Tasks           []ProjectTemplateTaskResponse `json:"tasks,omitempty"`
+}
@@ -0,0 +1,48 @@
+package web
+
+import "github.com/google/uuid"
+
+type TaskBulkFilter struct {
+	ProjectId  *uuid.UUID `json:"project_id"`
+	Status     *string    `json:"status" validate:"omitempty,oneof=todo in-progress completed"`
+	Priority   *string    `json:"priority" validate:"omitempty,oneof=low medium high"`
+	AssigneeId *uuid.UUID `json:"assignee_id"`
+	Label      *string    `json:"label"`
+}
+
+type TaskBulkPatch struct {
+	Status     *string    `json:"status" validate:"omitempty,oneof=todo in-progress completed"`
+	Priority   *string    `json:"priority" validate:"omitempty,oneof=low medium high"`
+	Labels     *[]string  `json:"labels"`
+	AssigneeId *uuid.UUID `json:"assignee_id"`
+	// UnassignAssignee menghapus assignee (karena assignee_id null tidak bisa dibedakan dari "tidak diubah")
+	UnassignAssignee bool       `json:"unassign_assignee"`
+	ProjectId        *uuid.UUID `json:"project_id"`
+}
+
+// TaskBulkRequest menerapkan patch atau delete ke daftar task (TaskIds)
+// atau ke semua task yang cocok dengan Filter.
+type TaskBulkRequest struct {
+	Action       string          `json:"action" validate:"required,oneof=update delete"`
+	TaskIds      []uuid.UUID     `json:"task_ids" validate:"required_without=Filter"`
+	Filter       *TaskBulkFilter `json:"filter" validate:"required_without=TaskIds"`
+	Patch        *TaskBulkPatch  `json:"patch" validate:"required_if=Action update"`
+	AllOrNothing bool            `json:"all_or_nothing"`
+}
+
+type TaskBulkItemResult struct {
+	TaskId  uuid.UUID `json:"task_id"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type TaskBulkResponse struct {
+	Action       string `json:"action"`
+	AllOrNothing bool   `json:"all_or_nothing"`
+	Total        int    `json:"total"`
+	Succeeded    int    `json:"succeeded"`
+	Failed       int    `json:"failed"`
+	// Committed false berarti semua perubahan dibatalkan (mode all-or-nothing)
+	Committed bool                 `json:"committed"`
+	Results   []TaskBulkItemResult `json:"results"`
+}
@@ -0,0 +1,34 @@
+package web
+
+import "github.com/google/uuid"
+
+// TaskImportRequest mengimpor task dari file CSV ke project (ProjectId dari path)
+type TaskImportRequest struct {
+	ProjectId uuid.UUID `json:"-"`
+	// Mapping memetakan field task (nama JSON, mis. "title") ke nama kolom di
+	// header CSV. Field yang tidak dipetakan dibaca dari kolom bernama sama.
+	Mapping map[string]string `json:"mapping"`
+	// DryRun memvalidasi semua baris tanpa menyimpan apa pun
+	DryRun bool `json:"dry_run"`
+	// AllOrNothing membatalkan seluruh import jika ada satu baris saja yang gagal
+	AllOrNothing bool `json:"all_or_nothing"`
+}
+
+type TaskImportRowResult struct {
+	// Row adalah nomor baris di file CSV (header adalah baris 1)
+	Row     int        `json:"row"`
+	Success bool       `json:"success"`
+	TaskId  *uuid.UUID `json:"task_id,omitempty"`
+	Errors  []string   `json:"errors,omitempty"`
+}
+
+type TaskImportResponse struct {
+	DryRun       bool `json:"dry_run"`
+	AllOrNothing bool `json:"all_or_nothing"`
+	Total        int  `json:"total"`
+	Succeeded    int  `json:"succeeded"`
+	Failed       int  `json:"failed"`
+	// Committed false berarti tidak ada task yang disimpan (dry run atau all-or-nothing gagal)
+	Committed bool                  `json:"committed"`
+	Results   []TaskImportRowResult `json:"results"`
+}
@@ -15,6 +15,9 @@ type TaskCreateRequest struct {
 	DifficultyLevel string    `json:"difficulty_level"`
 	Deliverable    string    `json:"deliverable"`
 	Bottleneck     string    `json:"bottleneck"`
+	AssigneeId     *uuid.UUID `json:"assignee_id"`
+	Labels         []string  `json:"labels"`
+	DueDate        *time.Time `json:"due_date"`
 }
 
 type TaskUpdateRequest struct {
@@ -27,6 +30,11 @@ type TaskUpdateRequest struct {
 	Bottleneck     *string   `json:"bottleneck"`
 	ContinueTomorrow *bool    `json:"continue_tomorrow"`
 	Progress       *string   `json:"progress"`
+	AssigneeId     *uuid.UUID `json:"assignee_id"`
+	Labels         *[]string `json:"labels"`
+	DueDate        *time.Time `json:"due_date"`
+	// ExpectedUpdatedAt (opsional) menolak update jika task sudah diubah orang lain sejak dibaca
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at"`
 }
 
 type TaskResponse struct {
@@ -41,6 +49,9 @@ type TaskResponse struct {
 	Bottleneck     string    `json:"bottleneck"`
 	ContinueTomorrow bool     `json:"continue_tomorrow"`
 	Progress       string    `json:"progress"`
+	AssigneeId     *uuid.UUID `json:"assignee_id"`
+	Labels         []string  `json:"labels"`
+	DueDate        *time.Time `json:"due_date"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashItemResponse adalah project atau task yang sedang berada di trash
+type TrashItemResponse struct {
+	// Type bernilai "project" atau "task"
+	Type      string    `json:"type"`
+	Id        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ProjectId uuid.UUID `json:"project_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// PurgeAt adalah waktu item akan dihapus permanen
+	PurgeAt time.Time `json:"purge_at"`
+}
@@ -5,6 +5,9 @@ type UserRegisterRequest struct {
     Email    string `json:"email" validate:"required,email"`
     Password string `json:"password" validate:"required"`
     Role     string `json:"role" validate:"required,oneof=SE SCE"`
+    // WorkspaceName nama workspace baru untuk pendaftaran publik; diabaikan jika
+    // user dibuat oleh anggota workspace yang sedang login
+    WorkspaceName string `json:"workspace_name"`
 }
 
 type UserLoginRequest struct {
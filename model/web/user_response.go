@@ -7,12 +7,14 @@ import (
 )
 
 type UserResponse struct {
-	Id        uuid.UUID  `json:"id"`
-	Email     string     `json:"email"`
-	Password  string     `json:"password"`
-	Role      string     `json:"role"`
-	FullName  string     `json:"full_name"`
-	CreatedAt *time.Time `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	Id       uuid.UUID `json:"id"`
+	Email    string    `json:"email"`
+	Password string    `json:"password"`
+	Role     string    `json:"role"`
+	FullName string    `json:"full_name"`
+	// WorkspaceId adalah workspace (tenant) pemilik user
+	WorkspaceId uuid.UUID  `json:"workspace_id"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
 }
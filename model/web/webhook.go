@@ -0,0 +1,57 @@
+package web
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookCreateRequest struct {
+	// ProjectId kosong berarti webhook untuk semua project di workspace
+	ProjectId *uuid.UUID `json:"project_id"`
+	Url       string     `validate:"required,http_url" json:"url"`
+	// Events kosong berarti semua event
+	Events []string `validate:"dive,oneof=task.created task.updated task.status_changed task.deleted project.created project.updated project.status_changed project.deleted" json:"events"`
+}
+
+type WebhookUpdateRequest struct {
+	Id     uuid.UUID `json:"-"`
+	Url    string    `validate:"required,http_url" json:"url"`
+	Events []string  `validate:"dive,oneof=task.created task.updated task.status_changed task.deleted project.created project.updated project.status_changed project.deleted" json:"events"`
+	// Active nil berarti status aktif tidak diubah
+	Active *bool `json:"active"`
+}
+
+type WebhookResponse struct {
+	Id          uuid.UUID  `json:"id"`
+	WorkspaceId uuid.UUID  `json:"workspace_id"`
+	ProjectId   *uuid.UUID `json:"project_id"`
+	Url         string     `json:"url"`
+	// Secret hanya dikirim sekali saat webhook dibuat
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             uuid.UUID  `json:"id"`
+	WebhookId      uuid.UUID  `json:"webhook_id"`
+	EventId        uuid.UUID  `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	LastStatusCode *int       `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	LastResponse   string     `json:"last_response"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	RedeliveryOf   *uuid.UUID `json:"redelivery_of"`
+	CreatedAt      time.Time  `json:"created_at"`
+	// Payload adalah body yang dikirim, apa adanya
+	Payload json.RawMessage `json:"payload"`
+}
@@ -0,0 +1,18 @@
+package web
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkspaceUpdateRequest struct {
+	Name string `validate:"required" json:"name"`
+}
+
+type WorkspaceResponse struct {
+	Id        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
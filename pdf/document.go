@@ -0,0 +1,175 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Ukuran halaman A4 dalam point (1/72 inci)
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Color adalah warna RGB dengan komponen 0-1
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black = Color{0, 0, 0}
+	White = Color{1, 1, 1}
+)
+
+// Gray membuat warna abu-abu; 0 hitam, 1 putih
+func Gray(level float64) Color {
+	return Color{level, level, level}
+}
+
+// Document adalah dokumen PDF sederhana berisi teks, garis dan kotak.
+// Semua koordinat diukur dari kiri atas halaman, y bertambah ke bawah.
+type Document struct {
+	Title     string
+	CreatedAt time.Time
+	pages     []*bytes.Buffer
+	current   int
+}
+
+func NewDocument(title string) *Document {
+	return &Document{Title: title, CreatedAt: time.Now()}
+}
+
+// AddPage menambah halaman baru dan menjadikannya halaman aktif
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.current = len(d.pages) - 1
+}
+
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// SetPage memilih halaman aktif (mulai dari 0), mis. untuk menulis footer
+func (d *Document) SetPage(index int) {
+	d.current = index
+}
+
+// Text menulis satu baris teks; y adalah posisi baseline
+func (d *Document) Text(x, y float64, font Font, size float64, color Color, text string) {
+	fmt.Fprintf(d.page(), "BT /F%d %s Tf %s rg 1 0 0 1 %s %s Tm (%s) Tj ET\n",
+		font+1, number(size), colorOperands(color), number(x), number(PageHeight-y), escapeString(encodeWinAnsi(text)))
+}
+
+// Rect mengisi kotak dengan sudut kiri atas (x, y)
+func (d *Document) Rect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(d.page(), "%s rg %s %s %s %s re f\n",
+		colorOperands(color), number(x), number(PageHeight-y-height), number(width), number(height))
+}
+
+// Line menggambar garis lurus
+func (d *Document) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(d.page(), "%s RG %s w %s %s m %s %s l S\n",
+		colorOperands(color), number(width), number(x1), number(PageHeight-y1), number(x2), number(PageHeight-y2))
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[d.current]
+}
+
+// WriteTo menulis dokumen PDF 1.4 lengkap: objek, tabel xref dan trailer
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	// Objek: 1 catalog, 2 pages, 3-4 font, 5 info, lalu page dan content tiap halaman
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	pageObject := func(index int) int { return 6 + index*2 }
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := ""
+	for i := range d.pages {
+		kids += fmt.Sprintf("%d 0 R ", pageObject(i))
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, len(d.pages)))
+	for _, name := range fontBaseNames {
+		object("<< /Type /Font /Subtype /Type1 /BaseFont /" + name + " /Encoding /WinAnsiEncoding >>")
+	}
+	object(fmt.Sprintf("<< /Title %s /Producer (task-management) /CreationDate (D:%s) >>",
+		textString(d.Title), d.CreatedAt.UTC().Format("20060102150405Z")))
+
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			number(PageWidth), number(PageHeight), pageObject(i)+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.WriteTo(w)
+}
+
+// number menulis angka dengan paling banyak dua desimal
+func number(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 2, 64)
+	formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	if formatted == "-0" || formatted == "" {
+		return "0"
+	}
+	return formatted
+}
+
+func colorOperands(color Color) string {
+	return number(color.R) + " " + number(color.G) + " " + number(color.B)
+}
+
+// escapeString meng-escape string literal PDF
+func escapeString(text []byte) string {
+	var escaped bytes.Buffer
+	for _, b := range text {
+		if b == '(' || b == ')' || b == '\\' {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteByte(b)
+	}
+	return escaped.String()
+}
+
+// textString menulis string metadata sebagai UTF-16BE agar karakter apa pun tersimpan
+func textString(text string) string {
+	hex := "<FEFF"
+	for _, unit := range utf16.Encode([]rune(text)) {
+		hex += fmt.Sprintf("%04X", unit)
+	}
+	return hex + ">"
+}
@@ -0,0 +1,73 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestNumberAndEscapeString(t *testing.T) {
+	numbers := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0"}, {12, "12"}, {1.5, "1.5"}, {1.234, "1.23"}, {-0.001, "0"}, {-2.50, "-2.5"},
+	}
+	for _, test := range numbers {
+		if got := number(test.value); got != test.want {
+			t.Errorf("number(%v) = %q, want %q", test.value, got, test.want)
+		}
+	}
+
+	if got := escapeString([]byte(`a(b)\c`)); got != `a\(b\)\\c` {
+		t.Errorf("escapeString = %q", got)
+	}
+}
+
+func TestDocumentWriteTo(t *testing.T) {
+	document := NewDocument("Laporan (Q1)")
+	flow := NewFlow(document, 40)
+	flow.Heading(18, "Laporan")
+	for i := 0; i < 120; i++ {
+		flow.Paragraph(Helvetica, 10, Black, "Baris ke-"+strconv.Itoa(i))
+	}
+	if document.PageCount() < 2 {
+		t.Fatalf("long flow should span pages, got %d", document.PageCount())
+	}
+
+	var buffer bytes.Buffer
+	n, err := document.WriteTo(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := buffer.Bytes()
+	if n != int64(len(output)) {
+		t.Fatalf("WriteTo returned %d, wrote %d bytes", n, len(output))
+	}
+	if !bytes.HasPrefix(output, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(output, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(output)
+	if match == nil {
+		t.Fatal("missing startxref")
+	}
+	offset, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(output[offset:], []byte("xref\n")) {
+		t.Errorf("startxref %d does not point at the xref table", offset)
+	}
+
+	// Setiap entri xref harus menunjuk ke awal objek bernomor sama
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(output, -1)
+	for i, entry := range entries {
+		objectOffset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(output[objectOffset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")) {
+			t.Errorf("xref entry %d points at offset %d which is not its object", i+1, objectOffset)
+		}
+	}
+
+	if !bytes.Contains(output, []byte("/Count "+strconv.Itoa(document.PageCount())+" >>")) {
+		t.Errorf("page tree count does not match %d pages", document.PageCount())
+	}
+}
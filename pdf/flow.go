@@ -0,0 +1,196 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// lineHeight adalah jarak antar baris relatif terhadap ukuran font
+	lineHeight = 1.3
+	// cellPadding adalah jarak teks ke tepi sel tabel
+	cellPadding = 4
+	// maxCellLines membatasi tinggi satu sel agar satu baris tabel selalu muat di satu halaman
+	maxCellLines = 20
+)
+
+// Flow menyusun konten dari atas ke bawah halaman dan otomatis pindah ke
+// halaman baru saat ruang tidak cukup
+type Flow struct {
+	Document *Document
+	Margin   float64
+	y        float64
+}
+
+// NewFlow membuat halaman pertama dokumen dan mulai menulis di margin atas
+func NewFlow(document *Document, margin float64) *Flow {
+	document.AddPage()
+	return &Flow{Document: document, Margin: margin, y: margin}
+}
+
+// Width adalah lebar area konten di antara margin kiri dan kanan
+func (f *Flow) Width() float64 {
+	return PageWidth - 2*f.Margin
+}
+
+// Space menambah jarak vertikal
+func (f *Flow) Space(height float64) {
+	f.y += height
+}
+
+// ensure pindah ke halaman baru jika tinggi height tidak muat lagi
+func (f *Flow) ensure(height float64) bool {
+	if f.y+height <= PageHeight-f.Margin {
+		return false
+	}
+	f.Document.AddPage()
+	f.y = f.Margin
+	return true
+}
+
+// Paragraph menulis teks yang dibungkus sesuai lebar konten
+func (f *Flow) Paragraph(font Font, size float64, color Color, text string) {
+	for _, line := range WrapText(font, size, text, f.Width()) {
+		f.ensure(size * lineHeight)
+		f.Document.Text(f.Margin, f.y+baselineOffset(size), font, size, color, line)
+		f.y += size * lineHeight
+	}
+}
+
+// Heading menulis judul bagian; judul tidak ditinggal sendirian di bawah halaman
+func (f *Flow) Heading(size float64, text string) {
+	f.ensure(size*lineHeight + 40)
+	f.Paragraph(HelveticaBold, size, Black, text)
+	f.Space(size * 0.3)
+}
+
+// ProgressBar menggambar batang progress dengan fraction 0-1
+func (f *Flow) ProgressBar(fraction float64, height float64, fill Color) {
+	fraction = max(0, min(1, fraction))
+	f.ensure(height)
+	f.Document.Rect(f.Margin, f.y, f.Width(), height, Gray(0.9))
+	if fraction > 0 {
+		f.Document.Rect(f.Margin, f.y, f.Width()*fraction, height, fill)
+	}
+	f.y += height
+}
+
+// Column adalah kolom tabel; Width adalah bagian dari lebar konten (0-1)
+type Column struct {
+	Title string
+	Width float64
+}
+
+// Table menulis tabel dengan header abu-abu. Teks sel dibungkus, dan header
+// diulang di atas setiap halaman lanjutan.
+func (f *Flow) Table(size float64, columns []Column, rows [][]string) {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Title
+	}
+
+	headerHeight := f.rowHeight(HelveticaBold, size, columns, header)
+	// Header selalu ditulis bersama paling tidak satu baris data
+	firstRowHeight := headerHeight
+	if len(rows) > 0 {
+		firstRowHeight += f.rowHeight(Helvetica, size, columns, rows[0])
+	}
+	f.ensure(firstRowHeight)
+	f.tableRow(HelveticaBold, size, columns, header, Gray(0.9))
+
+	for _, row := range rows {
+		if f.ensure(f.rowHeight(Helvetica, size, columns, row)) {
+			f.tableRow(HelveticaBold, size, columns, header, Gray(0.9))
+		}
+		f.tableRow(Helvetica, size, columns, row, White)
+	}
+}
+
+func (f *Flow) rowHeight(font Font, size float64, columns []Column, cells []string) float64 {
+	lines := 1
+	for i, column := range columns {
+		lines = max(lines, len(f.cellLines(font, size, column, cells[i])))
+	}
+	return float64(lines)*size*lineHeight + 2*cellPadding
+}
+
+func (f *Flow) cellLines(font Font, size float64, column Column, text string) []string {
+	lines := WrapText(font, size, text, column.Width*f.Width()-2*cellPadding)
+	if len(lines) > maxCellLines {
+		lines = append(lines[:maxCellLines-1], "...")
+	}
+	return lines
+}
+
+func (f *Flow) tableRow(font Font, size float64, columns []Column, cells []string, background Color) {
+	height := f.rowHeight(font, size, columns, cells)
+	if background != White {
+		f.Document.Rect(f.Margin, f.y, f.Width(), height, background)
+	}
+
+	x := f.Margin
+	for i, column := range columns {
+		for lineIndex, line := range f.cellLines(font, size, column, cells[i]) {
+			top := f.y + cellPadding + float64(lineIndex)*size*lineHeight
+			f.Document.Text(x+cellPadding, top+baselineOffset(size), font, size, Black, line)
+		}
+		x += column.Width * f.Width()
+	}
+
+	f.y += height
+	f.Document.Line(f.Margin, f.y, f.Margin+f.Width(), f.y, 0.5, Gray(0.8))
+}
+
+// Footer menulis teks dan nomor halaman di bagian bawah setiap halaman.
+// Dipanggil setelah semua konten ditulis agar jumlah halaman sudah diketahui.
+func (f *Flow) Footer(size float64, text string) {
+	total := f.Document.PageCount()
+	for page := 0; page < total; page++ {
+		f.Document.SetPage(page)
+		y := PageHeight - f.Margin/2
+		f.Document.Text(f.Margin, y, Helvetica, size, Gray(0.4), text)
+
+		pageNumber := "Halaman " + strconv.Itoa(page+1) + " dari " + strconv.Itoa(total)
+		f.Document.Text(PageWidth-f.Margin-TextWidth(Helvetica, size, pageNumber), y, Helvetica, size, Gray(0.4), pageNumber)
+	}
+	f.Document.SetPage(total - 1)
+}
+
+// baselineOffset adalah jarak dari atas baris ke baseline teks, sehingga
+// huruf kapital berada di tengah tinggi baris
+func baselineOffset(size float64) float64 {
+	return size*lineHeight/2 + size*0.3
+}
+
+// WrapText memecah teks menjadi baris yang lebarnya tidak melebihi width.
+// Baris baru di teks dipertahankan; kata yang lebih panjang dari satu baris dipotong.
+func WrapText(font Font, size float64, text string, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Kata yang terlalu panjang dipotong per karakter
+			line = ""
+			for _, r := range word {
+				if line != "" && TextWidth(font, size, line+string(r)) > width {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
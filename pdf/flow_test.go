@@ -0,0 +1,30 @@
+package pdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	// Pada ukuran 1000 satu huruf "a" = 556 point dan spasi = 278 point
+	tests := []struct {
+		name  string
+		text  string
+		width float64
+		want  []string
+	}{
+		{"fits", "aa aa", 2600, []string{"aa aa"}},
+		{"wraps at word", "aa aa aa", 2600, []string{"aa aa", "aa"}},
+		{"keeps newlines", "aa\r\n\naa", 2000, []string{"aa", "", "aa"}},
+		{"breaks long word", "aaaaa", 1200, []string{"aa", "aa", "a"}},
+		{"empty", "", 1000, []string{""}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := WrapText(Helvetica, 1000, test.text, test.width)
+			if strings.Join(got, "|") != strings.Join(test.want, "|") {
+				t.Errorf("WrapText(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
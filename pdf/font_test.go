@@ -0,0 +1,43 @@
+package pdf
+
+import "testing"
+
+func TestEncodeWinAnsi(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Tugas A", "Tugas A"},
+		{"a\tb", "a b"},
+		{"café", "caf\xE9"},
+		{"€ — “ok”", "\x80 \x97 \x93ok\x94"},
+		{"✓ 日本", "? ??"},
+	}
+	for _, test := range tests {
+		if got := string(encodeWinAnsi(test.text)); got != test.want {
+			t.Errorf("encodeWinAnsi(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		size float64
+		text string
+		want float64
+	}{
+		{Helvetica, 10, "", 0},
+		{Helvetica, 10, "a", 5.56},
+		{Helvetica, 1000, "a a", 556 + 278 + 556},
+		{Helvetica, 10, "日", 5.56},
+	}
+	for _, test := range tests {
+		if got := TextWidth(test.font, test.size, test.text); got != test.want {
+			t.Errorf("TextWidth(%q, %v) = %v, want %v", test.text, test.size, got, test.want)
+		}
+	}
+	if TextWidth(HelveticaBold, 10, "W") <= TextWidth(Helvetica, 10, "i") {
+		t.Error("bold W should be wider than regular i")
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvatarStorage menyimpan file thumbnail avatar per user dan ukuran
+type AvatarStorage interface {
+	Save(userId uuid.UUID, size int, data []byte) error
+	// Open membuka thumbnail beserta waktu terakhir diubah (untuk header cache)
+	Open(userId uuid.UUID, size int) (io.ReadSeekCloser, time.Time, error)
+	Delete(userId uuid.UUID) error
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvatarStorageImpl menyimpan avatar di filesystem: <dir>/<userId>/<size>.png
+type AvatarStorageImpl struct {
+	Dir string
+}
+
+func NewAvatarStorage(dir string) AvatarStorage {
+	return &AvatarStorageImpl{Dir: dir}
+}
+
+func (s *AvatarStorageImpl) Save(userId uuid.UUID, size int, data []byte) error {
+	dir := filepath.Join(s.Dir, userId.String())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	// Tulis ke file sementara lalu rename, agar request yang sedang membaca
+	// tidak pernah mendapat file setengah jadi
+	temp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), s.path(userId, size))
+}
+
+func (s *AvatarStorageImpl) Open(userId uuid.UUID, size int) (io.ReadSeekCloser, time.Time, error) {
+	file, err := os.Open(s.path(userId, size))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, time.Time{}, errors.New("avatar not found")
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, time.Time{}, err
+	}
+	return file, info.ModTime(), nil
+}
+
+func (s *AvatarStorageImpl) Delete(userId uuid.UUID) error {
+	return os.RemoveAll(filepath.Join(s.Dir, userId.String()))
+}
+
+func (s *AvatarStorageImpl) path(userId uuid.UUID, size int) string {
+	return filepath.Join(s.Dir, userId.String(), strconv.Itoa(size)+".png")
+}
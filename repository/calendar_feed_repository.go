@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type CalendarFeedRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, feed domain.CalendarFeed) domain.CalendarFeed
+	// Revoke menandai feed tidak berlaku lagi; feed yang sudah di-revoke tidak berubah
+	Revoke(ctx context.Context, tx *sql.Tx, feed domain.CalendarFeed) domain.CalendarFeed
+	FindById(ctx context.Context, tx *sql.Tx, feedId uuid.UUID) (domain.CalendarFeed, error)
+	FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) []domain.CalendarFeed
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type CalendarFeedRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewCalendarFeedRepository(db *sql.DB) CalendarFeedRepository {
+	return &CalendarFeedRepositoryImpl{DB: db}
+}
+
+const calendarFeedColumns = `id, user_id, component, scope, revoked_at, created_at`
+
+func (r *CalendarFeedRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, feed domain.CalendarFeed) domain.CalendarFeed {
+	if feed.Id == uuid.Nil {
+		feed.Id = uuid.New()
+	}
+	feed.CreatedAt = helper.Now()
+
+	SQL := `INSERT INTO calendar_feeds(` + calendarFeedColumns + `) VALUES($1, $2, $3, $4, $5, $6)`
+	args := []interface{}{feed.Id, feed.UserId, feed.Component, feed.Scope, feed.RevokedAt, feed.CreatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return feed
+}
+
+func (r *CalendarFeedRepositoryImpl) Revoke(ctx context.Context, tx *sql.Tx, feed domain.CalendarFeed) domain.CalendarFeed {
+	if feed.RevokedAt != nil {
+		return feed
+	}
+	now := helper.Now()
+	feed.RevokedAt = &now
+
+	SQL := "UPDATE calendar_feeds SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, feed.RevokedAt, feed.Id)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, feed.RevokedAt, feed.Id)
+	}
+	helper.PanicIfError(err)
+	return feed
+}
+
+func (r *CalendarFeedRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, feedId uuid.UUID) (domain.CalendarFeed, error) {
+	SQL := `SELECT ` + calendarFeedColumns + ` FROM calendar_feeds WHERE id = $1`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, feedId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, feedId)
+	}
+
+	feed, err := scanCalendarFeed(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return feed, errors.New("calendar feed not found")
+	}
+	return feed, err
+}
+
+func (r *CalendarFeedRepositoryImpl) FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) []domain.CalendarFeed {
+	SQL := `SELECT ` + calendarFeedColumns + ` FROM calendar_feeds WHERE user_id = $1 ORDER BY created_at DESC`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, userId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, userId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var feeds []domain.CalendarFeed
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		helper.PanicIfError(err)
+		feeds = append(feeds, feed)
+	}
+	return feeds
+}
+
+func scanCalendarFeed(scanner rowScanner) (domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	err := scanner.Scan(&feed.Id, &feed.UserId, &feed.Component, &feed.Scope, &feed.RevokedAt, &feed.CreatedAt)
+	return feed, err
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type EmailDigestItemRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, item domain.EmailDigestItem) domain.EmailDigestItem
+	// FindPendingUserIds mengambil user yang masih punya item belum masuk digest
+	FindPendingUserIds(ctx context.Context, tx *sql.Tx) []uuid.UUID
+	// FindPendingByUserId mengunci (FOR UPDATE SKIP LOCKED) item yang belum masuk
+	// digest, urut dari yang terlama; worker lain tidak mengambil item yang sama
+	FindPendingByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) []domain.EmailDigestItem
+	MarkDigested(ctx context.Context, tx *sql.Tx, itemIds []uuid.UUID, digestedAt time.Time)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type EmailDigestItemRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewEmailDigestItemRepository(db *sql.DB) EmailDigestItemRepository {
+	return &EmailDigestItemRepositoryImpl{DB: db}
+}
+
+const emailDigestItemColumns = `id, user_id, kind, task_id, task_title, project_id, project_name, actor_name, excerpt,
+	digested_at, created_at`
+
+func (r *EmailDigestItemRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, item domain.EmailDigestItem) domain.EmailDigestItem {
+	if item.Id == uuid.Nil {
+		item.Id = uuid.New()
+	}
+	item.CreatedAt = helper.Now()
+
+	SQL := `INSERT INTO email_digest_items(` + emailDigestItemColumns + `)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	args := []interface{}{item.Id, item.UserId, item.Kind, item.TaskId, item.TaskTitle, item.ProjectId, item.ProjectName,
+		item.ActorName, item.Excerpt, item.DigestedAt, item.CreatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return item
+}
+
+func (r *EmailDigestItemRepositoryImpl) FindPendingUserIds(ctx context.Context, tx *sql.Tx) []uuid.UUID {
+	SQL := `SELECT DISTINCT user_id FROM email_digest_items WHERE digested_at IS NULL`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var userIds []uuid.UUID
+	for rows.Next() {
+		var userId uuid.UUID
+		helper.PanicIfError(rows.Scan(&userId))
+		userIds = append(userIds, userId)
+	}
+	return userIds
+}
+
+func (r *EmailDigestItemRepositoryImpl) FindPendingByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) []domain.EmailDigestItem {
+	SQL := `SELECT ` + emailDigestItemColumns + ` FROM email_digest_items
+		WHERE user_id = $1 AND digested_at IS NULL ORDER BY created_at FOR UPDATE SKIP LOCKED`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, userId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, userId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var items []domain.EmailDigestItem
+	for rows.Next() {
+		var item domain.EmailDigestItem
+		err := rows.Scan(&item.Id, &item.UserId, &item.Kind, &item.TaskId, &item.TaskTitle, &item.ProjectId, &item.ProjectName,
+			&item.ActorName, &item.Excerpt, &item.DigestedAt, &item.CreatedAt)
+		helper.PanicIfError(err)
+		items = append(items, item)
+	}
+	return items
+}
+
+func (r *EmailDigestItemRepositoryImpl) MarkDigested(ctx context.Context, tx *sql.Tx, itemIds []uuid.UUID, digestedAt time.Time) {
+	ids := make([]string, len(itemIds))
+	for i, itemId := range itemIds {
+		ids[i] = itemId.String()
+	}
+	SQL := `UPDATE email_digest_items SET digested_at = $1 WHERE id = ANY($2::uuid[])`
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, digestedAt, pq.Array(ids))
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, digestedAt, pq.Array(ids))
+	}
+	helper.PanicIfError(err)
+}
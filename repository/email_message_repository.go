@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"task-management/model/domain"
+)
+
+type EmailMessageRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, message domain.EmailMessage) domain.EmailMessage
+	// ClaimDue mengambil email pending yang sudah jatuh tempo dan memundurkan
+	// next_attempt_at sebesar lease, jadi worker lain tidak mengirim email yang sama.
+	ClaimDue(ctx context.Context, tx *sql.Tx, now time.Time, lease time.Duration, limit int) []domain.EmailMessage
+	// UpdateResult menyimpan status, attempts, next_attempt_at, last_error dan sent_at
+	UpdateResult(ctx context.Context, tx *sql.Tx, message domain.EmailMessage)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type EmailMessageRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewEmailMessageRepository(db *sql.DB) EmailMessageRepository {
+	return &EmailMessageRepositoryImpl{DB: db}
+}
+
+const emailMessageColumns = `id, user_id, to_address, subject, html_body, text_body, unsubscribe_url, status, attempts,
+	next_attempt_at, last_attempt_at, last_error, sent_at, created_at`
+
+func (r *EmailMessageRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, message domain.EmailMessage) domain.EmailMessage {
+	if message.Id == uuid.Nil {
+		message.Id = uuid.New()
+	}
+	if message.Status == "" {
+		message.Status = domain.EmailPending
+	}
+	message.CreatedAt = helper.Now()
+	if message.NextAttemptAt.IsZero() {
+		message.NextAttemptAt = message.CreatedAt
+	}
+
+	SQL := `INSERT INTO email_messages(` + emailMessageColumns + `)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	args := []interface{}{message.Id, message.UserId, message.ToAddress, message.Subject, message.HtmlBody, message.TextBody,
+		message.UnsubscribeUrl, message.Status, message.Attempts, message.NextAttemptAt, message.LastAttemptAt,
+		message.LastError, message.SentAt, message.CreatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return message
+}
+
+func (r *EmailMessageRepositoryImpl) ClaimDue(ctx context.Context, tx *sql.Tx, now time.Time, lease time.Duration, limit int) []domain.EmailMessage {
+	SQL := `UPDATE email_messages SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM email_messages
+			WHERE status = $2 AND next_attempt_at <= $3
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + emailMessageColumns
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, now.Add(lease), domain.EmailPending, now, limit)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, now.Add(lease), domain.EmailPending, now, limit)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var messages []domain.EmailMessage
+	for rows.Next() {
+		message, err := scanEmailMessage(rows)
+		helper.PanicIfError(err)
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func (r *EmailMessageRepositoryImpl) UpdateResult(ctx context.Context, tx *sql.Tx, message domain.EmailMessage) {
+	SQL := `UPDATE email_messages SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4,
+		last_error = $5, sent_at = $6 WHERE id = $7`
+	args := []interface{}{message.Status, message.Attempts, message.NextAttemptAt, message.LastAttemptAt,
+		message.LastError, message.SentAt, message.Id}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+}
+
+func scanEmailMessage(scanner rowScanner) (domain.EmailMessage, error) {
+	var message domain.EmailMessage
+	err := scanner.Scan(&message.Id, &message.UserId, &message.ToAddress, &message.Subject, &message.HtmlBody, &message.TextBody,
+		&message.UnsubscribeUrl, &message.Status, &message.Attempts, &message.NextAttemptAt, &message.LastAttemptAt,
+		&message.LastError, &message.SentAt, &message.CreatedAt)
+	return message, err
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type NotificationPreferenceRepository interface {
+	// Save menyimpan preference (insert atau update berdasarkan user_id)
+	Save(ctx context.Context, tx *sql.Tx, preference domain.NotificationPreference) domain.NotificationPreference
+	FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) (domain.NotificationPreference, error)
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type NotificationPreferenceRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewNotificationPreferenceRepository(db *sql.DB) NotificationPreferenceRepository {
+	return &NotificationPreferenceRepositoryImpl{DB: db}
+}
+
+const notificationPreferenceColumns = `user_id, email_mode, digest_hour, last_digest_at, updated_at`
+
+func (r *NotificationPreferenceRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, preference domain.NotificationPreference) domain.NotificationPreference {
+	preference.UpdatedAt = helper.Now()
+
+	SQL := `INSERT INTO notification_preferences(` + notificationPreferenceColumns + `) VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET email_mode = EXCLUDED.email_mode, digest_hour = EXCLUDED.digest_hour,
+		last_digest_at = EXCLUDED.last_digest_at, updated_at = EXCLUDED.updated_at`
+	args := []interface{}{preference.UserId, preference.EmailMode, preference.DigestHour, preference.LastDigestAt, preference.UpdatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return preference
+}
+
+func (r *NotificationPreferenceRepositoryImpl) FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) (domain.NotificationPreference, error) {
+	SQL := `SELECT ` + notificationPreferenceColumns + ` FROM notification_preferences WHERE user_id = $1`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, userId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, userId)
+	}
+
+	var preference domain.NotificationPreference
+	err := row.Scan(&preference.UserId, &preference.EmailMode, &preference.DigestHour, &preference.LastDigestAt, &preference.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return preference, errors.New("notification preference not found")
+	}
+	return preference, err
+}
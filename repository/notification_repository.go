@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type NotificationRepository interface {
+	// Save menyimpan notifikasi; notifikasi dengan DedupeKey yang sudah ada
+	// untuk user yang sama dilewati dan mengembalikan false
+	Save(ctx context.Context, tx *sql.Tx, notification domain.Notification) (domain.Notification, bool)
+	// FindByUserId mengambil notifikasi terbaru; before (opsional) untuk halaman berikutnya
+	FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID, unreadOnly bool, before *time.Time, limit int) []domain.Notification
+	CountUnread(ctx context.Context, tx *sql.Tx, userId uuid.UUID) int
+	// MarkRead mengisi read_at (nil = tandai belum dibaca) untuk notifikasi milik user
+	MarkRead(ctx context.Context, tx *sql.Tx, userId uuid.UUID, notificationIds []uuid.UUID, readAt *time.Time) int64
+	MarkAllRead(ctx context.Context, tx *sql.Tx, userId uuid.UUID, readAt time.Time) int64
+	// DeleteOld menghapus notifikasi terbaca sebelum readBefore dan semua notifikasi sebelum before
+	DeleteOld(ctx context.Context, tx *sql.Tx, readBefore time.Time, before time.Time) int64
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type NotificationRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &NotificationRepositoryImpl{DB: db}
+}
+
+const notificationColumns = `id, user_id, kind, project_id, task_id, actor_id, title, message, dedupe_key, read_at, created_at`
+
+func (r *NotificationRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, notification domain.Notification) (domain.Notification, bool) {
+	if notification.Id == uuid.Nil {
+		notification.Id = uuid.New()
+	}
+	notification.CreatedAt = helper.Now()
+
+	SQL := `INSERT INTO notifications(` + notificationColumns + `)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (user_id, dedupe_key) DO NOTHING`
+	args := []interface{}{notification.Id, notification.UserId, notification.Kind, notification.ProjectId, notification.TaskId,
+		notification.ActorId, notification.Title, notification.Message, notification.DedupeKey, notification.ReadAt,
+		notification.CreatedAt}
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	inserted, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return notification, inserted > 0
+}
+
+func (r *NotificationRepositoryImpl) FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID, unreadOnly bool, before *time.Time, limit int) []domain.Notification {
+	SQL := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = $1`
+	args := []interface{}{userId}
+	if unreadOnly {
+		SQL += ` AND read_at IS NULL`
+	}
+	if before != nil {
+		args = append(args, *before)
+		SQL += fmt.Sprintf(` AND created_at < $%d`, len(args))
+	}
+	args = append(args, limit)
+	SQL += fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d`, len(args))
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, args...)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	notifications := []domain.Notification{}
+	for rows.Next() {
+		var notification domain.Notification
+		err := rows.Scan(&notification.Id, &notification.UserId, &notification.Kind, &notification.ProjectId, &notification.TaskId,
+			&notification.ActorId, &notification.Title, &notification.Message, &notification.DedupeKey, &notification.ReadAt,
+			&notification.CreatedAt)
+		helper.PanicIfError(err)
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
+
+func (r *NotificationRepositoryImpl) CountUnread(ctx context.Context, tx *sql.Tx, userId uuid.UUID) int {
+	SQL := `SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, userId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, userId)
+	}
+
+	var count int
+	helper.PanicIfError(row.Scan(&count))
+	return count
+}
+
+func (r *NotificationRepositoryImpl) MarkRead(ctx context.Context, tx *sql.Tx, userId uuid.UUID, notificationIds []uuid.UUID, readAt *time.Time) int64 {
+	ids := make([]string, len(notificationIds))
+	for i, notificationId := range notificationIds {
+		ids[i] = notificationId.String()
+	}
+	SQL := `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND id = ANY($3::uuid[])`
+
+	return r.exec(ctx, tx, SQL, readAt, userId, pq.Array(ids))
+}
+
+func (r *NotificationRepositoryImpl) MarkAllRead(ctx context.Context, tx *sql.Tx, userId uuid.UUID, readAt time.Time) int64 {
+	SQL := `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL`
+
+	return r.exec(ctx, tx, SQL, readAt, userId)
+}
+
+func (r *NotificationRepositoryImpl) DeleteOld(ctx context.Context, tx *sql.Tx, readBefore time.Time, before time.Time) int64 {
+	SQL := `DELETE FROM notifications WHERE (read_at IS NOT NULL AND created_at < $1) OR created_at < $2`
+
+	return r.exec(ctx, tx, SQL, readBefore, before)
+}
+
+func (r *NotificationRepositoryImpl) exec(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) int64 {
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		result, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return affected
+}
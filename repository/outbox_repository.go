@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type OutboxRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) domain.OutboxEvent
+	// ClaimNext mengunci (FOR UPDATE SKIP LOCKED) event belum terkirim yang sudah
+	// jatuh tempo dan merupakan event tertua aggregate-nya. Event berikutnya dari
+	// aggregate yang sama baru bisa diambil setelah event ini terkirim.
+	ClaimNext(ctx context.Context, tx *sql.Tx, now time.Time, limit int) []domain.OutboxEvent
+	MarkPublished(ctx context.Context, tx *sql.Tx, eventId uuid.UUID, publishedAt time.Time)
+	// MarkFailed menyimpan attempts, next_attempt_at dan last_error
+	MarkFailed(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent)
+	// DeletePublished menghapus event yang sudah terkirim sebelum waktu tertentu
+	DeletePublished(ctx context.Context, tx *sql.Tx, before time.Time) int64
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type OutboxRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+	return &OutboxRepositoryImpl{DB: db}
+}
+
+const outboxEventColumns = `id, sequence, aggregate_type, aggregate_id, event_type, workspace_id, payload,
+	attempts, next_attempt_at, last_error, published_at, created_at`
+
+func (r *OutboxRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) domain.OutboxEvent {
+	if event.Id == uuid.Nil {
+		event.Id = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = helper.Now()
+	}
+	event.NextAttemptAt = event.CreatedAt
+
+	// Sequence diisi oleh bigserial
+	SQL := `INSERT INTO outbox_events(id, aggregate_type, aggregate_id, event_type, workspace_id, payload, next_attempt_at, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING sequence`
+	args := []interface{}{event.Id, event.AggregateType, event.AggregateId, event.EventType, event.WorkspaceId,
+		event.Payload, event.NextAttemptAt, event.CreatedAt}
+
+	var err error
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, SQL, args...).Scan(&event.Sequence)
+	} else {
+		err = r.DB.QueryRowContext(ctx, SQL, args...).Scan(&event.Sequence)
+	}
+	helper.PanicIfError(err)
+	return event
+}
+
+func (r *OutboxRepositoryImpl) ClaimNext(ctx context.Context, tx *sql.Tx, now time.Time, limit int) []domain.OutboxEvent {
+	SQL := `SELECT ` + outboxEventColumns + ` FROM outbox_events o
+		WHERE o.published_at IS NULL AND o.next_attempt_at <= $1
+		AND NOT EXISTS (
+			SELECT 1 FROM outbox_events earlier
+			WHERE earlier.aggregate_type = o.aggregate_type AND earlier.aggregate_id = o.aggregate_id
+			AND earlier.published_at IS NULL AND earlier.sequence < o.sequence
+		)
+		ORDER BY o.sequence
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, now, limit)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, now, limit)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		var event domain.OutboxEvent
+		err := rows.Scan(&event.Id, &event.Sequence, &event.AggregateType, &event.AggregateId, &event.EventType,
+			&event.WorkspaceId, &event.Payload, &event.Attempts, &event.NextAttemptAt, &event.LastError,
+			&event.PublishedAt, &event.CreatedAt)
+		helper.PanicIfError(err)
+		events = append(events, event)
+	}
+	return events
+}
+
+func (r *OutboxRepositoryImpl) MarkPublished(ctx context.Context, tx *sql.Tx, eventId uuid.UUID, publishedAt time.Time) {
+	SQL := "UPDATE outbox_events SET published_at = $1, last_error = '' WHERE id = $2"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, publishedAt, eventId)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, publishedAt, eventId)
+	}
+	helper.PanicIfError(err)
+}
+
+func (r *OutboxRepositoryImpl) MarkFailed(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) {
+	SQL := "UPDATE outbox_events SET attempts = $1, next_attempt_at = $2, last_error = $3 WHERE id = $4"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, event.Attempts, event.NextAttemptAt, event.LastError, event.Id)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, event.Attempts, event.NextAttemptAt, event.LastError, event.Id)
+	}
+	helper.PanicIfError(err)
+}
+
+func (r *OutboxRepositoryImpl) DeletePublished(ctx context.Context, tx *sql.Tx, before time.Time) int64 {
+	SQL := "DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < $1"
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, SQL, before)
+	} else {
+		result, err = r.DB.ExecContext(ctx, SQL, before)
+	}
+	helper.PanicIfError(err)
+
+	deleted, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return deleted
+}
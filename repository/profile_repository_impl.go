@@ -10,6 +10,9 @@ import (
 	"task-management/model/domain"
 )
 
+const profileColumns = `id, user_id, full_name, email, role, job_title, timezone, locale,
+	working_hours_start, working_hours_end, bio, avatar_updated_at`
+
 type ProfileRepositoryImpl struct {
 	DB *sql.DB
 }
@@ -22,48 +25,59 @@ func (r *ProfileRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, profile do
 	if profile.Id == uuid.Nil {
 		profile.Id = uuid.New()
 	}
-	SQL := "INSERT INTO profiles(id, user_id, full_name, email, role) VALUES($1, $2, $3, $4, $5)"
+	if profile.Timezone == "" {
+		profile.Timezone = domain.DefaultProfileTimezone
+	}
+	if profile.Locale == "" {
+		profile.Locale = domain.DefaultProfileLocale
+	}
+	SQL := "INSERT INTO profiles(" + profileColumns + ") VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)"
+	args := []interface{}{
+		profile.Id,
+		profile.UserId,
+		strings.TrimSpace(profile.FullName),
+		strings.ToLower(strings.TrimSpace(profile.Email)),
+		profile.Role,
+		strings.TrimSpace(profile.JobTitle),
+		profile.Timezone,
+		profile.Locale,
+		profile.WorkingHoursStart,
+		profile.WorkingHoursEnd,
+		strings.TrimSpace(profile.Bio),
+		profile.AvatarUpdatedAt,
+	}
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, SQL,
-			profile.Id,
-			profile.UserId,
-			strings.TrimSpace(profile.FullName),
-			strings.ToLower(strings.TrimSpace(profile.Email)),
-			profile.Role,
-		)
+		_, err = tx.ExecContext(ctx, SQL, args...)
 	} else {
-		_, err = r.DB.ExecContext(ctx, SQL,
-			profile.Id,
-			profile.UserId,
-			strings.TrimSpace(profile.FullName),
-			strings.ToLower(strings.TrimSpace(profile.Email)),
-			profile.Role,
-		)
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
 	}
 	helper.PanicIfError(err)
 	return profile
 }
 
 func (r *ProfileRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, profile domain.Profile) domain.Profile {
-	SQL := "UPDATE profiles SET user_id = $1, full_name = $2, email = $3, role = $4 WHERE id = $5"
+	SQL := `UPDATE profiles SET user_id = $1, full_name = $2, email = $3, role = $4, job_title = $5, timezone = $6,
+		locale = $7, working_hours_start = $8, working_hours_end = $9, bio = $10, avatar_updated_at = $11 WHERE id = $12`
+	args := []interface{}{
+		profile.UserId,
+		strings.TrimSpace(profile.FullName),
+		strings.ToLower(strings.TrimSpace(profile.Email)),
+		profile.Role,
+		strings.TrimSpace(profile.JobTitle),
+		profile.Timezone,
+		profile.Locale,
+		profile.WorkingHoursStart,
+		profile.WorkingHoursEnd,
+		strings.TrimSpace(profile.Bio),
+		profile.AvatarUpdatedAt,
+		profile.Id,
+	}
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, SQL,
-			profile.UserId,
-			strings.TrimSpace(profile.FullName),
-			strings.ToLower(strings.TrimSpace(profile.Email)),
-			profile.Role,
-			profile.Id,
-		)
+		_, err = tx.ExecContext(ctx, SQL, args...)
 	} else {
-		_, err = r.DB.ExecContext(ctx, SQL,
-			profile.UserId,
-			strings.TrimSpace(profile.FullName),
-			strings.ToLower(strings.TrimSpace(profile.Email)),
-			profile.Role,
-			profile.Id,
-		)
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
 	}
 	helper.PanicIfError(err)
 	return profile
@@ -81,26 +95,14 @@ func (r *ProfileRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, profileI
 }
 
 func (r *ProfileRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, profileId uuid.UUID) (domain.Profile, error) {
-	SQL := "SELECT id, user_id, full_name, email, role FROM profiles WHERE id = $1"
-	var profile domain.Profile
-	var err error
+	SQL := "SELECT " + profileColumns + " FROM profiles WHERE id = $1"
+	var row *sql.Row
 	if tx != nil {
-		err = tx.QueryRowContext(ctx, SQL, profileId).Scan(
-			&profile.Id,
-			&profile.UserId,
-			&profile.FullName,
-			&profile.Email,
-			&profile.Role,
-		)
+		row = tx.QueryRowContext(ctx, SQL, profileId)
 	} else {
-		err = r.DB.QueryRowContext(ctx, SQL, profileId).Scan(
-			&profile.Id,
-			&profile.UserId,
-			&profile.FullName,
-			&profile.Email,
-			&profile.Role,
-		)
+		row = r.DB.QueryRowContext(ctx, SQL, profileId)
 	}
+	profile, err := scanProfile(row)
 	if err == sql.ErrNoRows {
 		return profile, errors.New("profile not found")
 	}
@@ -109,26 +111,14 @@ func (r *ProfileRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, profil
 }
 
 func (r *ProfileRepositoryImpl) FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) (domain.Profile, error) {
-	SQL := "SELECT id, user_id, full_name, email, role FROM profiles WHERE user_id = $1"
-	var profile domain.Profile
-	var err error
+	SQL := "SELECT " + profileColumns + " FROM profiles WHERE user_id = $1"
+	var row *sql.Row
 	if tx != nil {
-		err = tx.QueryRowContext(ctx, SQL, userId).Scan(
-			&profile.Id,
-			&profile.UserId,
-			&profile.FullName,
-			&profile.Email,
-			&profile.Role,
-		)
+		row = tx.QueryRowContext(ctx, SQL, userId)
 	} else {
-		err = r.DB.QueryRowContext(ctx, SQL, userId).Scan(
-			&profile.Id,
-			&profile.UserId,
-			&profile.FullName,
-			&profile.Email,
-			&profile.Role,
-		)
+		row = r.DB.QueryRowContext(ctx, SQL, userId)
 	}
+	profile, err := scanProfile(row)
 	if err == sql.ErrNoRows {
 		return profile, errors.New("profile not found")
 	}
@@ -137,7 +127,7 @@ func (r *ProfileRepositoryImpl) FindByUserId(ctx context.Context, tx *sql.Tx, us
 }
 
 func (r *ProfileRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Profile {
-	SQL := "SELECT id, user_id, full_name, email, role FROM profiles"
+	SQL := "SELECT " + profileColumns + " FROM profiles"
 	var profiles []domain.Profile
 	var rows *sql.Rows
 	var err error
@@ -150,16 +140,28 @@ func (r *ProfileRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domai
 	defer rows.Close()
 
 	for rows.Next() {
-		var profile domain.Profile
-		err := rows.Scan(
-			&profile.Id,
-			&profile.UserId,
-			&profile.FullName,
-			&profile.Email,
-			&profile.Role,
-		)
+		profile, err := scanProfile(rows)
 		helper.PanicIfError(err)
 		profiles = append(profiles, profile)
 	}
 	return profiles
-}
\ No newline at end of file
+}
+
+func scanProfile(row rowScanner) (domain.Profile, error) {
+	var profile domain.Profile
+	err := row.Scan(
+		&profile.Id,
+		&profile.UserId,
+		&profile.FullName,
+		&profile.Email,
+		&profile.Role,
+		&profile.JobTitle,
+		&profile.Timezone,
+		&profile.Locale,
+		&profile.WorkingHoursStart,
+		&profile.WorkingHoursEnd,
+		&profile.Bio,
+		&profile.AvatarUpdatedAt,
+	)
+	return profile, err
+}
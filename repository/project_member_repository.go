@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type ProjectMemberRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, member domain.ProjectMember) domain.ProjectMember
+	Update(ctx context.Context, tx *sql.Tx, member domain.ProjectMember) domain.ProjectMember
+	Delete(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, userId uuid.UUID) error
+	FindByProjectAndUser(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, userId uuid.UUID) (domain.ProjectMember, error)
+	FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) []domain.ProjectMember
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type ProjectMemberRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewProjectMemberRepository(db *sql.DB) ProjectMemberRepository {
+	return &ProjectMemberRepositoryImpl{DB: db}
+}
+
+// Save menambahkan anggota; jika user sudah menjadi anggota, role-nya ditimpa
+func (r *ProjectMemberRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, member domain.ProjectMember) domain.ProjectMember {
+	member.CreatedAt = helper.Now()
+	member.UpdatedAt = helper.Now()
+
+	SQL := `INSERT INTO project_members(project_id, user_id, role, created_at, updated_at)
+			VALUES($1, $2, $3, $4, $5)
+			ON CONFLICT (project_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at`
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, member.ProjectId, member.UserId, member.Role, member.CreatedAt, member.UpdatedAt)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, member.ProjectId, member.UserId, member.Role, member.CreatedAt, member.UpdatedAt)
+	}
+	helper.PanicIfError(err)
+	return member
+}
+
+func (r *ProjectMemberRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, member domain.ProjectMember) domain.ProjectMember {
+	member.UpdatedAt = helper.Now()
+
+	SQL := "UPDATE project_members SET role = $1, updated_at = $2 WHERE project_id = $3 AND user_id = $4"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, member.Role, member.UpdatedAt, member.ProjectId, member.UserId)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, member.Role, member.UpdatedAt, member.ProjectId, member.UserId)
+	}
+	helper.PanicIfError(err)
+	return member
+}
+
+func (r *ProjectMemberRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, userId uuid.UUID) error {
+	SQL := "DELETE FROM project_members WHERE project_id = $1 AND user_id = $2"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, projectId, userId)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, projectId, userId)
+	}
+	return err
+}
+
+func (r *ProjectMemberRepositoryImpl) FindByProjectAndUser(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, userId uuid.UUID) (domain.ProjectMember, error) {
+	SQL := `SELECT m.project_id, m.user_id, m.role, m.created_at, m.updated_at, COALESCE(u.full_name, ''), COALESCE(u.email, '')
+			FROM project_members m LEFT JOIN users u ON u.id = m.user_id
+			WHERE m.project_id = $1 AND m.user_id = $2`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, projectId, userId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, projectId, userId)
+	}
+
+	member, err := scanProjectMember(row)
+	if err == sql.ErrNoRows {
+		return member, errors.New("project member not found")
+	}
+	helper.PanicIfError(err)
+	return member, nil
+}
+
+func (r *ProjectMemberRepositoryImpl) FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) []domain.ProjectMember {
+	SQL := `SELECT m.project_id, m.user_id, m.role, m.created_at, m.updated_at, COALESCE(u.full_name, ''), COALESCE(u.email, '')
+			FROM project_members m LEFT JOIN users u ON u.id = m.user_id
+			WHERE m.project_id = $1
+			ORDER BY m.created_at`
+
+	var members []domain.ProjectMember
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, projectId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, projectId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		member, err := scanProjectMember(rows)
+		helper.PanicIfError(err)
+		members = append(members, member)
+	}
+
+	return members
+}
+
+func scanProjectMember(row rowScanner) (domain.ProjectMember, error) {
+	var member domain.ProjectMember
+	err := row.Scan(
+		&member.ProjectId,
+		&member.UserId,
+		&member.Role,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+		&member.FullName,
+		&member.Email,
+	)
+	return member, err
+}
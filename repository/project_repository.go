@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"task-management/model/domain"
@@ -15,4 +16,9 @@ type ProjectRepository interface {
 	FindById(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) (domain.Project, error)
 	FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) []domain.Project
 	FindAll(ctx context.Context, tx *sql.Tx) []domain.Project
+	FindDeleted(ctx context.Context, tx *sql.Tx) []domain.Project
+	FindDeletedById(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) (domain.Project, error)
+	Restore(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) error
+	// Purge menghapus permanen project (beserta datanya) yang sudah di trash sejak sebelum before
+	Purge(ctx context.Context, tx *sql.Tx, before time.Time) int64
 }
@@ -19,87 +19,87 @@ func NewProjectRepository(db *sql.DB) ProjectRepository {
 	return &ProjectRepositoryImpl{DB: db}
 }
 
+const projectColumns = `id, name, description, progress, progress_mode, confidence, trend, status, archived_from_status, start_date, due_date, created_at, updated_at, user_id, workspace_id, deleted_at`
+
 func (r *ProjectRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, project domain.Project) domain.Project {
 	if project.Id == uuid.Nil {
 		project.Id = uuid.New()
 	}
-	project.CreatedAt = time.Now()
-	project.UpdatedAt = time.Now()
-
-	SQL := `INSERT INTO projects(
-		id, name, description, progress, confidence, trend, created_at, updated_at, user_id
-	) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	project.CreatedAt = helper.Now()
+	project.UpdatedAt = helper.Now()
+
+	SQL := `INSERT INTO projects(` + projectColumns + `) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	args := []interface{}{
+		project.Id,
+		project.Name,
+		project.Description,
+		project.Progress,
+		project.ProgressMode,
+		project.Confidence,
+		project.Trend,
+		project.Status,
+		project.ArchivedFromStatus,
+		project.StartDate,
+		project.DueDate,
+		project.CreatedAt,
+		project.UpdatedAt,
+		project.UserId,
+		project.WorkspaceId,
+		project.DeletedAt,
+	}
 
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, SQL,
-			project.Id,
-			project.Name,
-			project.Description,
-			project.Progress,
-			project.Confidence,
-			project.Trend,
-			project.CreatedAt,
-			project.UpdatedAt,
-			project.UserId,
-		)
+		_, err = tx.ExecContext(ctx, SQL, args...)
 	} else {
-		_, err = r.DB.ExecContext(ctx, SQL,
-			project.Id,
-			project.Name,
-			project.Description,
-			project.Progress,
-			project.Confidence,
-			project.Trend,
-			project.CreatedAt,
-			project.UpdatedAt,
-			project.UserId,
-		)
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
 	}
 	helper.PanicIfError(err)
 	return project
 }
 
 func (r *ProjectRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, project domain.Project) domain.Project {
-	project.UpdatedAt = time.Now()
-
-	SQL := `UPDATE projects 
-			SET name = $1, description = $2, progress = $3, confidence = $4, trend = $5, updated_at = $6 
-			WHERE id = $7`
+	project.UpdatedAt = helper.Now()
+
+	SQL := `UPDATE projects
+			SET name = $1, description = $2, progress = $3, progress_mode = $4, confidence = $5, trend = $6,
+				status = $7, archived_from_status = $8, start_date = $9, due_date = $10, updated_at = $11
+			WHERE id = $12 AND deleted_at IS NULL`
+
+	args := []interface{}{
+		project.Name,
+		project.Description,
+		project.Progress,
+		project.ProgressMode,
+		project.Confidence,
+		project.Trend,
+		project.Status,
+		project.ArchivedFromStatus,
+		project.StartDate,
+		project.DueDate,
+		project.UpdatedAt,
+		project.Id,
+	}
 
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, SQL,
-			project.Name,
-			project.Description,
-			project.Progress,
-			project.Confidence,
-			project.Trend,
-			project.UpdatedAt,
-			project.Id,
-		)
+		_, err = tx.ExecContext(ctx, SQL, args...)
 	} else {
-		_, err = r.DB.ExecContext(ctx, SQL,
-			project.Name,
-			project.Description,
-			project.Progress,
-			project.Confidence,
-			project.Trend,
-			project.UpdatedAt,
-			project.Id,
-		)
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
 	}
 	helper.PanicIfError(err)
 	return project
 }
 
+// Delete memindahkan project ke trash (soft delete)
 func (r *ProjectRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) error {
-	SQL := "DELETE FROM projects WHERE id = $1"
+	SQL := "UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, SQL, projectId)
+		_, err = tx.ExecContext(ctx, SQL, helper.Now(), projectId)
 	} else {
-		_, err = r.DB.ExecContext(ctx, SQL, projectId)
+		_, err = r.DB.ExecContext(ctx, SQL, helper.Now(), projectId)
 	}
 	if err != nil {
 		return err
@@ -108,37 +108,17 @@ func (r *ProjectRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, projectI
 }
 
 func (r *ProjectRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) (domain.Project, error) {
-	SQL := `SELECT id, name, description, progress, confidence, trend, created_at, updated_at, user_id 
-			FROM projects WHERE id = $1`
+	SQL := `SELECT ` + projectColumns + `
+			FROM projects WHERE id = $1 AND deleted_at IS NULL`
 
-	var project domain.Project
-	var err error
+	var row *sql.Row
 	if tx != nil {
-		err = tx.QueryRowContext(ctx, SQL, projectId).Scan(
-			&project.Id,
-			&project.Name,
-			&project.Description,
-			&project.Progress,
-			&project.Confidence,
-			&project.Trend,
-			&project.CreatedAt,
-			&project.UpdatedAt,
-			&project.UserId,
-		)
+		row = tx.QueryRowContext(ctx, SQL, projectId)
 	} else {
-		err = r.DB.QueryRowContext(ctx, SQL, projectId).Scan(
-			&project.Id,
-			&project.Name,
-			&project.Description,
-			&project.Progress,
-			&project.Confidence,
-			&project.Trend,
-			&project.CreatedAt,
-			&project.UpdatedAt,
-			&project.UserId,
-		)
+		row = r.DB.QueryRowContext(ctx, SQL, projectId)
 	}
 
+	project, err := scanProject(row)
 	if err == sql.ErrNoRows {
 		return project, errors.New("project not found")
 	}
@@ -147,75 +127,128 @@ func (r *ProjectRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, projec
 }
 
 func (r *ProjectRepositoryImpl) FindByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) []domain.Project {
-	SQL := `SELECT id, name, description, progress, confidence, trend, created_at, updated_at, user_id 
-			FROM projects WHERE user_id = $1`
+	// Project milik user ditambah project tempat user menjadi anggota
+	SQL := `SELECT ` + projectColumns + `
+			FROM projects
+			WHERE deleted_at IS NULL
+				AND (user_id = $1 OR id IN (SELECT project_id FROM project_members WHERE user_id = $1))`
 
-	var projects []domain.Project
-	var rows *sql.Rows
-	var err error
+	return r.findProjects(ctx, tx, SQL, userId)
+}
 
+func (r *ProjectRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Project {
+	SQL := `SELECT ` + projectColumns + `
+			FROM projects WHERE deleted_at IS NULL`
+
+	return r.findProjects(ctx, tx, SQL)
+}
+
+func (r *ProjectRepositoryImpl) FindDeleted(ctx context.Context, tx *sql.Tx) []domain.Project {
+	SQL := `SELECT ` + projectColumns + `
+			FROM projects WHERE deleted_at IS NOT NULL
+			ORDER BY deleted_at DESC`
+
+	return r.findProjects(ctx, tx, SQL)
+}
+
+func (r *ProjectRepositoryImpl) FindDeletedById(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) (domain.Project, error) {
+	SQL := `SELECT ` + projectColumns + `
+			FROM projects WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	var row *sql.Row
 	if tx != nil {
-		rows, err = tx.QueryContext(ctx, SQL, userId)
+		row = tx.QueryRowContext(ctx, SQL, projectId)
 	} else {
-		rows, err = r.DB.QueryContext(ctx, SQL, userId)
+		row = r.DB.QueryRowContext(ctx, SQL, projectId)
 	}
 
+	project, err := scanProject(row)
+	if err == sql.ErrNoRows {
+		return project, errors.New("project not found in trash")
+	}
 	helper.PanicIfError(err)
-	defer rows.Close()
+	return project, nil
+}
 
-	for rows.Next() {
-		var project domain.Project
-		err := rows.Scan(
-			&project.Id,
-			&project.Name,
-			&project.Description,
-			&project.Progress,
-			&project.Confidence,
-			&project.Trend,
-			&project.CreatedAt,
-			&project.UpdatedAt,
-			&project.UserId,
-		)
-		helper.PanicIfError(err)
-		projects = append(projects, project)
+func (r *ProjectRepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) error {
+	SQL := "UPDATE projects SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL"
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, helper.Now(), projectId)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, helper.Now(), projectId)
 	}
-
-	return projects
+	return err
 }
 
-func (r *ProjectRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Project {
-	SQL := `SELECT id, name, description, progress, confidence, trend, created_at, updated_at, user_id 
-			FROM projects`
+func (r *ProjectRepositoryImpl) Purge(ctx context.Context, tx *sql.Tx, before time.Time) int64 {
+	// Data turunan project (task, riwayat, snapshot, anggota, share link, webhook, ...)
+	// ikut terhapus lewat foreign key ON DELETE CASCADE, lihat app/foreign_keys.go
+	SQL := "DELETE FROM projects WHERE deleted_at IS NOT NULL AND deleted_at < $1"
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, SQL, before)
+	} else {
+		result, err = r.DB.ExecContext(ctx, SQL, before)
+	}
+	helper.PanicIfError(err)
 
+	rowsAffected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return rowsAffected
+}
+
+func (r *ProjectRepositoryImpl) findProjects(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.Project {
 	var projects []domain.Project
 	var rows *sql.Rows
 	var err error
 
 	if tx != nil {
-		rows, err = tx.QueryContext(ctx, SQL)
+		rows, err = tx.QueryContext(ctx, SQL, args...)
 	} else {
-		rows, err = r.DB.QueryContext(ctx, SQL)
+		rows, err = r.DB.QueryContext(ctx, SQL, args...)
 	}
 
 	helper.PanicIfError(err)
 	defer rows.Close()
 
 	for rows.Next() {
-		var project domain.Project
-		err := rows.Scan(
-			&project.Id,
-			&project.Name,
-			&project.Description,
-			&project.Progress,
-			&project.Confidence,
-			&project.Trend,
-			&project.CreatedAt,
-			&project.UpdatedAt,
-			&project.UserId,
-		)
+		project, err := scanProject(rows)
 		helper.PanicIfError(err)
 		projects = append(projects, project)
 	}
 
 	return projects
 }
+
+// scanProject membaca satu baris project (urutan kolom sesuai projectColumns)
+func scanProject(row rowScanner) (domain.Project, error) {
+	var project domain.Project
+	var status, archivedFromStatus sql.NullString
+	err := row.Scan(
+		&project.Id,
+		&project.Name,
+		&project.Description,
+		&project.Progress,
+		&project.ProgressMode,
+		&project.Confidence,
+		&project.Trend,
+		&status,
+		&archivedFromStatus,
+		&project.StartDate,
+		&project.DueDate,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+		&project.UserId,
+		&project.WorkspaceId,
+		&project.DeletedAt,
+	)
+	// Project lama sebelum ada lifecycle dianggap active
+	project.Status = domain.ProjectStatusActive
+	if status.Valid && status.String != "" {
+		project.Status = status.String
+	}
+	project.ArchivedFromStatus = archivedFromStatus.String
+	return project, err
+}
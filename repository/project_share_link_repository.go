@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type ProjectShareLinkRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, link domain.ProjectShareLink) domain.ProjectShareLink
+	// Revoke menandai link tidak berlaku lagi; link yang sudah di-revoke tidak berubah
+	Revoke(ctx context.Context, tx *sql.Tx, link domain.ProjectShareLink) domain.ProjectShareLink
+	FindById(ctx context.Context, tx *sql.Tx, linkId uuid.UUID) (domain.ProjectShareLink, error)
+	FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) []domain.ProjectShareLink
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type ProjectShareLinkRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewProjectShareLinkRepository(db *sql.DB) ProjectShareLinkRepository {
+	return &ProjectShareLinkRepositoryImpl{DB: db}
+}
+
+const projectShareLinkColumns = `id, project_id, created_by, expires_at, revoked_at, created_at`
+
+func (r *ProjectShareLinkRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, link domain.ProjectShareLink) domain.ProjectShareLink {
+	if link.Id == uuid.Nil {
+		link.Id = uuid.New()
+	}
+	link.CreatedAt = helper.Now()
+
+	SQL := `INSERT INTO project_share_links(` + projectShareLinkColumns + `) VALUES($1, $2, $3, $4, $5, $6)`
+	args := []interface{}{link.Id, link.ProjectId, link.CreatedBy, link.ExpiresAt, link.RevokedAt, link.CreatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return link
+}
+
+func (r *ProjectShareLinkRepositoryImpl) Revoke(ctx context.Context, tx *sql.Tx, link domain.ProjectShareLink) domain.ProjectShareLink {
+	if link.RevokedAt != nil {
+		return link
+	}
+	now := helper.Now()
+	link.RevokedAt = &now
+
+	SQL := "UPDATE project_share_links SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, link.RevokedAt, link.Id)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, link.RevokedAt, link.Id)
+	}
+	helper.PanicIfError(err)
+	return link
+}
+
+func (r *ProjectShareLinkRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, linkId uuid.UUID) (domain.ProjectShareLink, error) {
+	SQL := `SELECT ` + projectShareLinkColumns + ` FROM project_share_links WHERE id = $1`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, linkId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, linkId)
+	}
+
+	link, err := scanProjectShareLink(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return link, errors.New("share link not found")
+	}
+	return link, err
+}
+
+func (r *ProjectShareLinkRepositoryImpl) FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) []domain.ProjectShareLink {
+	SQL := `SELECT ` + projectShareLinkColumns + ` FROM project_share_links WHERE project_id = $1 ORDER BY created_at DESC`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, projectId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, projectId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var links []domain.ProjectShareLink
+	for rows.Next() {
+		link, err := scanProjectShareLink(rows)
+		helper.PanicIfError(err)
+		links = append(links, link)
+	}
+	return links
+}
+
+func scanProjectShareLink(scanner rowScanner) (domain.ProjectShareLink, error) {
+	var link domain.ProjectShareLink
+	err := scanner.Scan(&link.Id, &link.ProjectId, &link.CreatedBy, &link.ExpiresAt, &link.RevokedAt, &link.CreatedAt)
+	return link, err
+}
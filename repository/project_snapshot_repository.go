@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type ProjectSnapshotRepository interface {
+	// Upsert menyimpan snapshot; snapshot di hari yang sama akan ditimpa.
+	Upsert(ctx context.Context, tx *sql.Tx, snapshot domain.ProjectSnapshot) domain.ProjectSnapshot
+	// FindByProjectId mengambil snapshot project di rentang tanggal [from, to], urut naik.
+	FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, from time.Time, to time.Time) []domain.ProjectSnapshot
+}
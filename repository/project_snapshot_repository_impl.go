@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type ProjectSnapshotRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewProjectSnapshotRepository(db *sql.DB) ProjectSnapshotRepository {
+	return &ProjectSnapshotRepositoryImpl{DB: db}
+}
+
+func (r *ProjectSnapshotRepositoryImpl) Upsert(ctx context.Context, tx *sql.Tx, snapshot domain.ProjectSnapshot) domain.ProjectSnapshot {
+	if snapshot.Id == uuid.Nil {
+		snapshot.Id = uuid.New()
+	}
+	snapshot.CreatedAt = helper.Now()
+
+	SQL := `INSERT INTO project_snapshots(
+		id, project_id, snapshot_date, progress, open_tasks, completed_effort, total_effort, created_at
+	) VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (project_id, snapshot_date) DO UPDATE SET
+		progress = EXCLUDED.progress,
+		open_tasks = EXCLUDED.open_tasks,
+		completed_effort = EXCLUDED.completed_effort,
+		total_effort = EXCLUDED.total_effort,
+		created_at = EXCLUDED.created_at`
+
+	args := []interface{}{
+		snapshot.Id,
+		snapshot.ProjectId,
+		snapshot.SnapshotDate.Format("2006-01-02"),
+		snapshot.Progress,
+		snapshot.OpenTasks,
+		snapshot.CompletedEffort,
+		snapshot.TotalEffort,
+		snapshot.CreatedAt,
+	}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return snapshot
+}
+
+func (r *ProjectSnapshotRepositoryImpl) FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, from time.Time, to time.Time) []domain.ProjectSnapshot {
+	SQL := `SELECT id, project_id, snapshot_date, progress, open_tasks, completed_effort, total_effort, created_at
+			FROM project_snapshots
+			WHERE project_id = $1 AND snapshot_date BETWEEN $2 AND $3
+			ORDER BY snapshot_date`
+
+	var snapshots []domain.ProjectSnapshot
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, projectId, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, projectId, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		var snapshot domain.ProjectSnapshot
+		err := rows.Scan(
+			&snapshot.Id,
+			&snapshot.ProjectId,
+			&snapshot.SnapshotDate,
+			&snapshot.Progress,
+			&snapshot.OpenTasks,
+			&snapshot.CompletedEffort,
+			&snapshot.TotalEffort,
+			&snapshot.CreatedAt,
+		)
+		helper.PanicIfError(err)
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots
+}
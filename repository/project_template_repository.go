@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type ProjectTemplateRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, template domain.ProjectTemplate) domain.ProjectTemplate
+	Delete(ctx context.Context, tx *sql.Tx, templateId uuid.UUID) error
+	FindById(ctx context.Context, tx *sql.Tx, templateId uuid.UUID) (domain.ProjectTemplate, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.ProjectTemplate
+}
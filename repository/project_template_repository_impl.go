@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type ProjectTemplateRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewProjectTemplateRepository(db *sql.DB) ProjectTemplateRepository {
+	return &ProjectTemplateRepositoryImpl{DB: db}
+}
+
+// Save menyimpan template beserta seluruh task-nya
+func (r *ProjectTemplateRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, template domain.ProjectTemplate) domain.ProjectTemplate {
+	if template.Id == uuid.Nil {
+		template.Id = uuid.New()
+	}
+	template.CreatedAt = helper.Now()
+	template.UpdatedAt = helper.Now()
+
+	SQL := `INSERT INTO project_templates(
+		id, name, description, source_project_id, duration_days, user_id, shared, workspace_id, created_at, updated_at
+	) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	args := []interface{}{
+		template.Id,
+		template.Name,
+		template.Description,
+		template.SourceProjectId,
+		template.DurationDays,
+		template.UserId,
+		template.Shared,
+		template.WorkspaceId,
+		template.CreatedAt,
+		template.UpdatedAt,
+	}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+
+	taskSQL := `INSERT INTO project_template_tasks(
+		id, template_id, position, title, priority, effort, difficulty_level, deliverable, labels, due_offset_days
+	) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	for i := range template.Tasks {
+		task := &template.Tasks[i]
+		if task.Id == uuid.Nil {
+			task.Id = uuid.New()
+		}
+		task.TemplateId = template.Id
+
+		taskArgs := []interface{}{
+			task.Id,
+			task.TemplateId,
+			task.Position,
+			task.Title,
+			task.Priority,
+			task.Effort,
+			task.DifficultyLevel,
+			task.Deliverable,
+			pq.Array(task.Labels),
+			task.DueOffsetDays,
+		}
+
+		if tx != nil {
+			_, err = tx.ExecContext(ctx, taskSQL, taskArgs...)
+		} else {
+			_, err = r.DB.ExecContext(ctx, taskSQL, taskArgs...)
+		}
+		helper.PanicIfError(err)
+	}
+
+	return template
+}
+
+func (r *ProjectTemplateRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, templateId uuid.UUID) error {
+	SQLs := []string{
+		"DELETE FROM project_template_tasks WHERE template_id = $1",
+		"DELETE FROM project_templates WHERE id = $1",
+	}
+	for _, SQL := range SQLs {
+		var err error
+		if tx != nil {
+			_, err = tx.ExecContext(ctx, SQL, templateId)
+		} else {
+			_, err = r.DB.ExecContext(ctx, SQL, templateId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindById mengambil template beserta task-nya (urut berdasarkan position)
+func (r *ProjectTemplateRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, templateId uuid.UUID) (domain.ProjectTemplate, error) {
+	SQL := `SELECT id, name, description, source_project_id, duration_days, user_id, shared, workspace_id, created_at, updated_at
+			FROM project_templates WHERE id = $1`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, templateId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, templateId)
+	}
+
+	template, err := scanProjectTemplate(row)
+	if err == sql.ErrNoRows {
+		return template, errors.New("project template not found")
+	}
+	helper.PanicIfError(err)
+
+	taskSQL := `SELECT id, template_id, position, title, priority, effort, difficulty_level, deliverable, labels, due_offset_days
+			FROM project_template_tasks WHERE template_id = $1 ORDER BY position`
+
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, taskSQL, templateId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, taskSQL, templateId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		var task domain.ProjectTemplateTask
+		var difficultyLevel, deliverable sql.NullString
+		err := rows.Scan(
+			&task.Id,
+			&task.TemplateId,
+			&task.Position,
+			&task.Title,
+			&task.Priority,
+			&task.Effort,
+			&difficultyLevel,
+			&deliverable,
+			pq.Array(&task.Labels),
+			&task.DueOffsetDays,
+		)
+		helper.PanicIfError(err)
+		task.DifficultyLevel = difficultyLevel.String
+		task.Deliverable = deliverable.String
+		template.Tasks = append(template.Tasks, task)
+	}
+
+	return template, nil
+}
+
+func (r *ProjectTemplateRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.ProjectTemplate {
+	SQL := `SELECT id, name, description, source_project_id, duration_days, user_id, shared, workspace_id, created_at, updated_at
+			FROM project_templates ORDER BY name`
+
+	var templates []domain.ProjectTemplate
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		template, err := scanProjectTemplate(rows)
+		helper.PanicIfError(err)
+		templates = append(templates, template)
+	}
+
+	return templates
+}
+
+func scanProjectTemplate(row rowScanner) (domain.ProjectTemplate, error) {
+	var template domain.ProjectTemplate
+	var description sql.NullString
+	err := row.Scan(
+		&template.Id,
+		&template.Name,
+		&description,
+		&template.SourceProjectId,
+		&template.DurationDays,
+		&template.UserId,
+		&template.Shared,
+		&template.WorkspaceId,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	template.Description = description.String
+	return template, err
+}
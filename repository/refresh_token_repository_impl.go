@@ -8,6 +8,8 @@ import (
     "time"
 )
 
+// RefreshTokenRepositoryImpl menyimpan token di memori proses, bukan di tabel
+// database, sehingga parameter tx diabaikan dan tidak ada RLS yang berlaku
 type RefreshTokenRepositoryImpl struct {
 }
 
@@ -2,16 +2,29 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"task-management/model/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TaskRepository interface {
-	Save(ctx context.Context, task domain.Task) (domain.Task, error)
-	Update(ctx context.Context, task domain.Task) (domain.Task, error)
-	Delete(ctx context.Context, taskId uuid.UUID) error
-	FindById(ctx context.Context, taskId uuid.UUID) (domain.Task, error)
-	FindByProjectId(ctx context.Context, projectId uuid.UUID) ([]domain.Task, error)
-	FindAll(ctx context.Context) ([]domain.Task, error)
-}
\ No newline at end of file
+	Save(ctx context.Context, tx *sql.Tx, task domain.Task) (domain.Task, error)
+	Update(ctx context.Context, tx *sql.Tx, task domain.Task) (domain.Task, error)
+	Delete(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) error
+	FindById(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) (domain.Task, error)
+	FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) ([]domain.Task, error)
+	FindByFilter(ctx context.Context, tx *sql.Tx, filter domain.TaskFilter) ([]domain.Task, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Task, error)
+	FindDeleted(ctx context.Context, tx *sql.Tx) ([]domain.Task, error)
+	FindDeletedById(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) (domain.Task, error)
+	Restore(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) error
+	Purge(ctx context.Context, tx *sql.Tx, before time.Time) (int64, error)
+	// FindWatchedByUserId mengambil task (bukan di trash) yang diikuti user
+	FindWatchedByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) ([]domain.Task, error)
+	// FindDueBetween mengambil task belum selesai yang punya assignee dan jatuh tempo di (from, to]
+	FindDueBetween(ctx context.Context, tx *sql.Tx, from time.Time, to time.Time) ([]domain.Task, error)
+	// FindDueByProjectIds mengambil task (bukan di trash) di project tertentu yang jatuh tempo sejak since
+	FindDueByProjectIds(ctx context.Context, tx *sql.Tx, projectIds []uuid.UUID, since time.Time) ([]domain.Task, error)
+}
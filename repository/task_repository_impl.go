@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"task-management/helper"
 	"task-management/model/domain"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type TaskRepositoryImpl struct {
@@ -20,18 +24,28 @@ func NewTaskRepository(db *sql.DB) TaskRepository {
 	}
 }
 
-func (repository *TaskRepositoryImpl) Save(ctx context.Context, task domain.Task) (domain.Task, error) {
-	query := `INSERT INTO tasks (id, project_id, title, status, priority, effort, difficulty_level, deliverable, bottleneck, progress, continue_tomorrow, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+const taskColumns = `id, project_id, title, status, priority, effort, difficulty_level, deliverable, bottleneck, progress, continue_tomorrow, assignee_id, labels, due_date, created_at, updated_at, deleted_at`
 
-	task.CreatedAt = time.Now()
-	task.UpdatedAt = time.Now()
+func (repository *TaskRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, task domain.Task) (domain.Task, error) {
+	query := `INSERT INTO tasks (` + taskColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
-	_, err := repository.DB.ExecContext(ctx, query,
+	task.CreatedAt = helper.Now()
+	task.UpdatedAt = helper.Now()
+
+	args := []interface{}{
 		task.Id, task.ProjectId, task.Title, task.Status, task.Priority,
 		task.Effort, task.DifficultyLevel, task.Deliverable, task.Bottleneck,
-		task.Progress, task.ContinueTomorrow,
-		task.CreatedAt, task.UpdatedAt)
+		task.Progress, task.ContinueTomorrow, task.AssigneeId, pq.Array(task.Labels),
+		task.DueDate, task.CreatedAt, task.UpdatedAt, task.DeletedAt,
+	}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = repository.DB.ExecContext(ctx, query, args...)
+	}
 
 	if err != nil {
 		return task, err
@@ -39,19 +53,30 @@ func (repository *TaskRepositoryImpl) Save(ctx context.Context, task domain.Task
 	return task, nil
 }
 
-func (repository *TaskRepositoryImpl) Update(ctx context.Context, task domain.Task) (domain.Task, error) {
-	query := `UPDATE tasks SET 
+func (repository *TaskRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, task domain.Task) (domain.Task, error) {
+	query := `UPDATE tasks SET
 		project_id = $1, title = $2, status = $3, priority = $4,
 		effort = $5, difficulty_level = $6, deliverable = $7, bottleneck = $8,
-		progress = $9, continue_tomorrow = $10, updated_at = $11
-		WHERE id = $12`
+		progress = $9, continue_tomorrow = $10, assignee_id = $11, labels = $12,
+		due_date = $13, updated_at = $14
+		WHERE id = $15 AND deleted_at IS NULL`
 
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = helper.Now()
 
-	result, err := repository.DB.ExecContext(ctx, query,
+	args := []interface{}{
 		task.ProjectId, task.Title, task.Status, task.Priority,
 		task.Effort, task.DifficultyLevel, task.Deliverable, task.Bottleneck,
-		task.Progress, task.ContinueTomorrow, task.UpdatedAt, task.Id)
+		task.Progress, task.ContinueTomorrow, task.AssigneeId, pq.Array(task.Labels),
+		task.DueDate, task.UpdatedAt, task.Id,
+	}
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		result, err = repository.DB.ExecContext(ctx, query, args...)
+	}
 
 	if err != nil {
 		return task, err
@@ -69,10 +94,17 @@ func (repository *TaskRepositoryImpl) Update(ctx context.Context, task domain.Ta
 	return task, nil
 }
 
-func (repository *TaskRepositoryImpl) Delete(ctx context.Context, taskId uuid.UUID) error {
-	query := `DELETE FROM tasks WHERE id = $1`
+// Delete memindahkan task ke trash (soft delete)
+func (repository *TaskRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) error {
+	query := `UPDATE tasks SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
 
-	result, err := repository.DB.ExecContext(ctx, query, taskId)
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, helper.Now(), taskId)
+	} else {
+		result, err = repository.DB.ExecContext(ctx, query, helper.Now(), taskId)
+	}
 	if err != nil {
 		return err
 	}
@@ -89,20 +121,18 @@ func (repository *TaskRepositoryImpl) Delete(ctx context.Context, taskId uuid.UU
 	return nil
 }
 
-func (repository *TaskRepositoryImpl) FindById(ctx context.Context, taskId uuid.UUID) (domain.Task, error) {
-	query := `SELECT id, project_id, title, status, priority, effort, difficulty_level, deliverable, bottleneck, progress, continue_tomorrow, created_at, updated_at
-		FROM tasks WHERE id = $1`
+func (repository *TaskRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) (domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks WHERE id = $1 AND deleted_at IS NULL`
 
-	var task domain.Task
-	var progress sql.NullString
-	var continueTomorrow sql.NullBool
-	
-	err := repository.DB.QueryRowContext(ctx, query, taskId).Scan(
-		&task.Id, &task.ProjectId, &task.Title, &task.Status, &task.Priority,
-		&task.Effort, &task.DifficultyLevel, &task.Deliverable, &task.Bottleneck,
-		&progress, &continueTomorrow,
-		&task.CreatedAt, &task.UpdatedAt)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, taskId)
+	} else {
+		row = repository.DB.QueryRowContext(ctx, query, taskId)
+	}
 
+	task, err := scanTask(row)
 	if err == sql.ErrNoRows {
 		return task, errors.New("task not found")
 	}
@@ -111,27 +141,169 @@ func (repository *TaskRepositoryImpl) FindById(ctx context.Context, taskId uuid.
 		return task, err
 	}
 
-	// Handle NULL values
-	if progress.Valid {
-		task.Progress = progress.String
+	return task, nil
+}
+
+func (repository *TaskRepositoryImpl) FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) ([]domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks WHERE project_id = $1 AND deleted_at IS NULL`
+
+	return repository.findTasks(ctx, tx, query, projectId)
+}
+
+func (repository *TaskRepositoryImpl) FindByFilter(ctx context.Context, tx *sql.Tx, filter domain.TaskFilter) ([]domain.Task, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+
+	// Tambahkan kondisi hanya untuk field filter yang diisi
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+	if filter.ProjectId != nil {
+		addCondition("project_id = $%d", *filter.ProjectId)
+	}
+	if filter.Status != nil {
+		addCondition("status = $%d", *filter.Status)
+	}
+	if filter.Priority != nil {
+		addCondition("priority = $%d", *filter.Priority)
+	}
+	if filter.AssigneeId != nil {
+		addCondition("assignee_id = $%d", *filter.AssigneeId)
+	}
+	if filter.Label != nil {
+		addCondition("$%d = ANY(labels)", *filter.Label)
+	}
+
+	query := `SELECT ` + taskColumns + `
+		FROM tasks WHERE ` + strings.Join(conditions, " AND ")
+
+	return repository.findTasks(ctx, tx, query, args...)
+}
+
+func (repository *TaskRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks WHERE deleted_at IS NULL`
+
+	return repository.findTasks(ctx, tx, query)
+}
+
+// FindDeleted mengambil task di trash yang project-nya masih ada.
+// Task milik project yang ikut terhapus ikut kembali saat project di-restore.
+func (repository *TaskRepositoryImpl) FindDeleted(ctx context.Context, tx *sql.Tx) ([]domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE deleted_at IS NOT NULL
+			AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)
+		ORDER BY deleted_at DESC`
+
+	return repository.findTasks(ctx, tx, query)
+}
+
+func (repository *TaskRepositoryImpl) FindWatchedByUserId(ctx context.Context, tx *sql.Tx, userId uuid.UUID) ([]domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE deleted_at IS NULL
+			AND id IN (SELECT task_id FROM task_watchers WHERE user_id = $1)
+			AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)
+		ORDER BY updated_at DESC`
+
+	return repository.findTasks(ctx, tx, query, userId)
+}
+
+func (repository *TaskRepositoryImpl) FindDueBetween(ctx context.Context, tx *sql.Tx, from time.Time, to time.Time) ([]domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE deleted_at IS NULL AND assignee_id IS NOT NULL AND status <> 'completed'
+			AND due_date > $1 AND due_date <= $2
+			AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)
+		ORDER BY due_date`
+
+	return repository.findTasks(ctx, tx, query, from, to)
+}
+
+func (repository *TaskRepositoryImpl) FindDueByProjectIds(ctx context.Context, tx *sql.Tx, projectIds []uuid.UUID, since time.Time) ([]domain.Task, error) {
+	ids := make([]string, len(projectIds))
+	for i, projectId := range projectIds {
+		ids[i] = projectId.String()
+	}
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE deleted_at IS NULL AND due_date >= $1 AND project_id = ANY($2::uuid[])
+		ORDER BY due_date`
+
+	return repository.findTasks(ctx, tx, query, since, pq.Array(ids))
+}
+
+func (repository *TaskRepositoryImpl) FindDeletedById(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) (domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+		FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, taskId)
 	} else {
-		task.Progress = ""
+		row = repository.DB.QueryRowContext(ctx, query, taskId)
 	}
-	
-	if continueTomorrow.Valid {
-		task.ContinueTomorrow = continueTomorrow.Bool
+
+	task, err := scanTask(row)
+	if err == sql.ErrNoRows {
+		return task, errors.New("task not found in trash")
+	}
+	return task, err
+}
+
+// Restore mengeluarkan task dari trash
+func (repository *TaskRepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) error {
+	query := `UPDATE tasks SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, helper.Now(), taskId)
 	} else {
-		task.ContinueTomorrow = false
+		result, err = repository.DB.ExecContext(ctx, query, helper.Now(), taskId)
+	}
+	if err != nil {
+		return err
 	}
 
-	return task, nil
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("task not found in trash")
+	}
+	return nil
 }
 
-func (repository *TaskRepositoryImpl) FindByProjectId(ctx context.Context, projectId uuid.UUID) ([]domain.Task, error) {
-	query := `SELECT id, project_id, title, status, priority, effort, difficulty_level, deliverable, bottleneck, progress, continue_tomorrow, created_at, updated_at
-		FROM tasks WHERE project_id = $1`
+// Purge menghapus permanen task yang sudah berada di trash sejak sebelum before
+func (repository *TaskRepositoryImpl) Purge(ctx context.Context, tx *sql.Tx, before time.Time) (int64, error) {
+	query := `DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < $1`
 
-	rows, err := repository.DB.QueryContext(ctx, query, projectId)
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, before)
+	} else {
+		result, err = repository.DB.ExecContext(ctx, query, before)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (repository *TaskRepositoryImpl) findTasks(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]domain.Task, error) {
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = repository.DB.QueryContext(ctx, query, args...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -139,32 +311,10 @@ func (repository *TaskRepositoryImpl) FindByProjectId(ctx context.Context, proje
 
 	var tasks []domain.Task
 	for rows.Next() {
-		var task domain.Task
-		var progress sql.NullString
-		var continueTomorrow sql.NullBool
-		
-		err := rows.Scan(
-			&task.Id, &task.ProjectId, &task.Title, &task.Status, &task.Priority,
-			&task.Effort, &task.DifficultyLevel, &task.Deliverable, &task.Bottleneck,
-			&progress, &continueTomorrow,
-			&task.CreatedAt, &task.UpdatedAt)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
-		
-		// Handle NULL values
-		if progress.Valid {
-			task.Progress = progress.String
-		} else {
-			task.Progress = ""
-		}
-		
-		if continueTomorrow.Valid {
-			task.ContinueTomorrow = continueTomorrow.Bool
-		} else {
-			task.ContinueTomorrow = false
-		}
-		
 		tasks = append(tasks, task)
 	}
 
@@ -175,50 +325,43 @@ func (repository *TaskRepositoryImpl) FindByProjectId(ctx context.Context, proje
 	return tasks, nil
 }
 
-func (repository *TaskRepositoryImpl) FindAll(ctx context.Context) ([]domain.Task, error) {
-	query := `SELECT id, project_id, title, status, priority, effort, difficulty_level, deliverable, bottleneck, progress, continue_tomorrow, created_at, updated_at
-		FROM tasks`
+// rowScanner dipenuhi oleh *sql.Row maupun *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask membaca satu baris task (urutan kolom sesuai taskColumns)
+func scanTask(row rowScanner) (domain.Task, error) {
+	var task domain.Task
+	var progress sql.NullString
+	var continueTomorrow sql.NullBool
+	var assigneeId uuid.NullUUID
 
-	rows, err := repository.DB.QueryContext(ctx, query)
+	err := row.Scan(
+		&task.Id, &task.ProjectId, &task.Title, &task.Status, &task.Priority,
+		&task.Effort, &task.DifficultyLevel, &task.Deliverable, &task.Bottleneck,
+		&progress, &continueTomorrow, &assigneeId, pq.Array(&task.Labels),
+		&task.DueDate, &task.CreatedAt, &task.UpdatedAt, &task.DeletedAt)
 	if err != nil {
-		return nil, err
+		return task, err
 	}
-	defer rows.Close()
 
-	var tasks []domain.Task
-	for rows.Next() {
-		var task domain.Task
-		var progress sql.NullString
-		var continueTomorrow sql.NullBool
-		
-		err := rows.Scan(
-			&task.Id, &task.ProjectId, &task.Title, &task.Status, &task.Priority,
-			&task.Effort, &task.DifficultyLevel, &task.Deliverable, &task.Bottleneck,
-			&progress, &continueTomorrow,
-			&task.CreatedAt, &task.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		
-		// Handle NULL values
-		if progress.Valid {
-			task.Progress = progress.String
-		} else {
-			task.Progress = ""
-		}
-		
-		if continueTomorrow.Valid {
-			task.ContinueTomorrow = continueTomorrow.Bool
-		} else {
-			task.ContinueTomorrow = false
-		}
-		
-		tasks = append(tasks, task)
+	// Handle NULL values
+	if progress.Valid {
+		task.Progress = progress.String
+	} else {
+		task.Progress = ""
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
+	if continueTomorrow.Valid {
+		task.ContinueTomorrow = continueTomorrow.Bool
+	} else {
+		task.ContinueTomorrow = false
 	}
 
-	return tasks, nil
-}
\ No newline at end of file
+	if assigneeId.Valid {
+		task.AssigneeId = &assigneeId.UUID
+	}
+
+	return task, nil
+}
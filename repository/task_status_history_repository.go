@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type TaskStatusHistoryRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, history domain.TaskStatusHistory) domain.TaskStatusHistory
+	// FindByProjectId mengambil seluruh riwayat project, urut naik berdasarkan waktu perubahan.
+	FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) []domain.TaskStatusHistory
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type TaskStatusHistoryRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewTaskStatusHistoryRepository(db *sql.DB) TaskStatusHistoryRepository {
+	return &TaskStatusHistoryRepositoryImpl{DB: db}
+}
+
+func (r *TaskStatusHistoryRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, history domain.TaskStatusHistory) domain.TaskStatusHistory {
+	if history.Id == uuid.Nil {
+		history.Id = uuid.New()
+	}
+	if history.ChangedAt.IsZero() {
+		history.ChangedAt = helper.Now()
+	}
+
+	SQL := `INSERT INTO task_status_histories(id, task_id, project_id, from_status, to_status, effort, changed_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7)`
+
+	args := []interface{}{
+		history.Id,
+		history.TaskId,
+		history.ProjectId,
+		history.FromStatus,
+		history.ToStatus,
+		history.Effort,
+		history.ChangedAt,
+	}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return history
+}
+
+func (r *TaskStatusHistoryRepositoryImpl) FindByProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) []domain.TaskStatusHistory {
+	SQL := `SELECT id, task_id, project_id, COALESCE(from_status, ''), to_status, effort, changed_at
+			FROM task_status_histories
+			WHERE project_id = $1
+			ORDER BY changed_at`
+
+	var histories []domain.TaskStatusHistory
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, projectId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, projectId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		var history domain.TaskStatusHistory
+		err := rows.Scan(
+			&history.Id,
+			&history.TaskId,
+			&history.ProjectId,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.Effort,
+			&history.ChangedAt,
+		)
+		helper.PanicIfError(err)
+		histories = append(histories, history)
+	}
+
+	return histories
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type TaskWatcherRepository interface {
+	// Save menambahkan watcher; user yang sudah menjadi watcher dilewati
+	Save(ctx context.Context, tx *sql.Tx, watcher domain.TaskWatcher)
+	Delete(ctx context.Context, tx *sql.Tx, taskId uuid.UUID, userId uuid.UUID)
+	FindUserIdsByTaskId(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) []uuid.UUID
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type TaskWatcherRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewTaskWatcherRepository(db *sql.DB) TaskWatcherRepository {
+	return &TaskWatcherRepositoryImpl{DB: db}
+}
+
+func (r *TaskWatcherRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, watcher domain.TaskWatcher) {
+	if watcher.CreatedAt.IsZero() {
+		watcher.CreatedAt = helper.Now()
+	}
+	SQL := `INSERT INTO task_watchers(task_id, user_id, created_at) VALUES($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, watcher.TaskId, watcher.UserId, watcher.CreatedAt)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, watcher.TaskId, watcher.UserId, watcher.CreatedAt)
+	}
+	helper.PanicIfError(err)
+}
+
+func (r *TaskWatcherRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, taskId uuid.UUID, userId uuid.UUID) {
+	SQL := `DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2`
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, taskId, userId)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, taskId, userId)
+	}
+	helper.PanicIfError(err)
+}
+
+func (r *TaskWatcherRepositoryImpl) FindUserIdsByTaskId(ctx context.Context, tx *sql.Tx, taskId uuid.UUID) []uuid.UUID {
+	SQL := `SELECT user_id FROM task_watchers WHERE task_id = $1 ORDER BY created_at`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, taskId)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, taskId)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var userIds []uuid.UUID
+	for rows.Next() {
+		var userId uuid.UUID
+		helper.PanicIfError(rows.Scan(&userId))
+		userIds = append(userIds, userId)
+	}
+	return userIds
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"strings"
-	"time"
 
 	"task-management/helper"
 	"task-management/model/domain"
@@ -24,7 +23,7 @@ func (r *UserRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user domain.U
 	if user.Id == uuid.Nil {
 		user.Id = uuid.New()
 	}
-	SQL := "INSERT INTO users(id, full_name, email, password_hash, role) VALUES($1, $2, $3, $4, $5)"
+	SQL := "INSERT INTO users(id, full_name, email, password_hash, role, workspace_id) VALUES($1, $2, $3, $4, $5, $6)"
 	var err error
 	if tx != nil {
 		_, err = tx.ExecContext(ctx, SQL,
@@ -33,6 +32,7 @@ func (r *UserRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user domain.U
 			strings.ToLower(strings.TrimSpace(user.Email)),
 			user.PasswordHash,
 			user.Role,
+			user.WorkspaceId,
 		)
 	} else {
 		_, err = r.DB.ExecContext(ctx, SQL,
@@ -41,6 +41,7 @@ func (r *UserRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user domain.U
 			strings.ToLower(strings.TrimSpace(user.Email)),
 			user.PasswordHash,
 			user.Role,
+			user.WorkspaceId,
 		)
 	}
 	helper.PanicIfError(err)
@@ -50,7 +51,14 @@ func (r *UserRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user domain.U
 func (r *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, user domain.User) domain.User {
 	// Ambil data lama
 	var oldUser domain.User
-	err := r.DB.QueryRowContext(ctx, "SELECT full_name, email, password_hash, role FROM users WHERE id=$1 AND deleted_at IS NULL", user.Id).Scan(
+	SQL := "SELECT full_name, email, password_hash, role FROM users WHERE id=$1 AND deleted_at IS NULL"
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, user.Id)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, user.Id)
+	}
+	err := row.Scan(
 		&oldUser.FullName,
 		&oldUser.Email,
 		&oldUser.PasswordHash,
@@ -72,7 +80,7 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, user domain
 		user.Role = oldUser.Role
 	}
 
-	SQL := "UPDATE users SET full_name=$1, email=$2, password_hash=$3, role=$4, updated_at=$5 WHERE id=$6 AND deleted_at IS NULL"
+	SQL = "UPDATE users SET full_name=$1, email=$2, password_hash=$3, role=$4, updated_at=$5 WHERE id=$6 AND deleted_at IS NULL"
 
 	if tx != nil {
 		_, err = tx.ExecContext(ctx, SQL,
@@ -80,7 +88,7 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, user domain
 			strings.ToLower(strings.TrimSpace(user.Email)),
 			user.PasswordHash,
 			user.Role,
-			time.Now(),
+			helper.Now(),
 			user.Id,
 		)
 	} else {
@@ -89,7 +97,7 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, user domain
 			strings.ToLower(strings.TrimSpace(user.Email)),
 			user.PasswordHash,
 			user.Role,
-			time.Now(),
+			helper.Now(),
 			user.Id,
 		)
 	}
@@ -102,15 +110,15 @@ func (r *UserRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, userId uuid
 	SQL := "UPDATE users SET deleted_at=$1 WHERE id=$2"
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, SQL, time.Now(), userId)
+		_, err = tx.ExecContext(ctx, SQL, helper.Now(), userId)
 	} else {
-		_, err = r.DB.ExecContext(ctx, SQL, time.Now(), userId)
+		_, err = r.DB.ExecContext(ctx, SQL, helper.Now(), userId)
 	}
 	helper.PanicIfError(err)
 }
 
 func (r *UserRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, userId uuid.UUID) (domain.User, error) {
-	SQL := "SELECT id, full_name, email, password_hash, role, created_at, updated_at, deleted_at FROM users WHERE id=$1 AND deleted_at IS NULL"
+	SQL := "SELECT id, full_name, email, password_hash, role, workspace_id, created_at, updated_at, deleted_at FROM users WHERE id=$1 AND deleted_at IS NULL"
 	var row *sql.Row
 	if tx != nil {
 		row = tx.QueryRowContext(ctx, SQL, userId)
@@ -119,7 +127,7 @@ func (r *UserRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, userId uu
 	}
 
 	user := domain.User{}
-	err := row.Scan(&user.Id, &user.FullName, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
+	err := row.Scan(&user.Id, &user.FullName, &user.Email, &user.PasswordHash, &user.Role, &user.WorkspaceId, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.User{Id: uuid.Nil}, nil
@@ -131,7 +139,7 @@ func (r *UserRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, userId uu
 
 func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, tx *sql.Tx, email string) (domain.User, error) {
 	email = strings.ToLower(strings.TrimSpace(email))
-	SQL := "SELECT id, full_name, email, password_hash, role, created_at, updated_at, deleted_at FROM users WHERE email=$1 AND deleted_at IS NULL"
+	SQL := "SELECT id, full_name, email, password_hash, role, workspace_id, created_at, updated_at, deleted_at FROM users WHERE email=$1 AND deleted_at IS NULL"
 
 	var row *sql.Row
 	if tx != nil {
@@ -141,7 +149,7 @@ func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, tx *sql.Tx, email
 	}
 
 	user := domain.User{}
-	err := row.Scan(&user.Id, &user.FullName, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
+	err := row.Scan(&user.Id, &user.FullName, &user.Email, &user.PasswordHash, &user.Role, &user.WorkspaceId, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.User{Id: uuid.Nil}, nil
@@ -152,7 +160,7 @@ func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, tx *sql.Tx, email
 }
 
 func (r *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.User {
-	SQL := "SELECT id, full_name, email, password_hash, role, created_at, updated_at, deleted_at FROM users WHERE deleted_at IS NULL"
+	SQL := "SELECT id, full_name, email, password_hash, role, workspace_id, created_at, updated_at, deleted_at FROM users WHERE deleted_at IS NULL"
 	var rows *sql.Rows
 	var err error
 	if tx != nil {
@@ -166,7 +174,7 @@ func (r *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.U
 	var users []domain.User
 	for rows.Next() {
 		user := domain.User{}
-		err := rows.Scan(&user.Id, &user.FullName, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
+		err := rows.Scan(&user.Id, &user.FullName, &user.Email, &user.PasswordHash, &user.Role, &user.WorkspaceId, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
 		helper.PanicIfError(err)
 		users = append(users, user)
 	}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery
+	// ClaimDue mengambil maksimal limit delivery pending yang sudah jatuh tempo dan
+	// menunda NextAttemptAt-nya selama lease, sehingga worker lain tidak mengambilnya
+	// (FOR UPDATE SKIP LOCKED). Jika worker mati, delivery diambil lagi setelah lease habis.
+	ClaimDue(ctx context.Context, tx *sql.Tx, now time.Time, lease time.Duration, limit int) []domain.WebhookDelivery
+	// UpdateResult menyimpan hasil percobaan pengiriman
+	UpdateResult(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery)
+	FindById(ctx context.Context, tx *sql.Tx, deliveryId uuid.UUID) (domain.WebhookDelivery, error)
+	// FindByWebhookId mengembalikan delivery terbaru lebih dulu
+	FindByWebhookId(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID, limit int) []domain.WebhookDelivery
+}
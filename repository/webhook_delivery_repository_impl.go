@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type WebhookDeliveryRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewWebhookDeliveryRepository(db *sql.DB) WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{DB: db}
+}
+
+const webhookDeliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_attempt_at, last_status_code, last_error, last_response, delivered_at, redelivery_of, created_at`
+
+func (r *WebhookDeliveryRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	if delivery.Id == uuid.Nil {
+		delivery.Id = uuid.New()
+	}
+	if delivery.Status == "" {
+		delivery.Status = domain.WebhookDeliveryPending
+	}
+	delivery.CreatedAt = helper.Now()
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = delivery.CreatedAt
+	}
+
+	SQL := `INSERT INTO webhook_deliveries(` + webhookDeliveryColumns + `)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	args := []interface{}{delivery.Id, delivery.WebhookId, delivery.EventId, delivery.EventType, delivery.Payload,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt, delivery.LastStatusCode,
+		delivery.LastError, delivery.LastResponse, delivery.DeliveredAt, delivery.RedeliveryOf, delivery.CreatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return delivery
+}
+
+func (r *WebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, tx *sql.Tx, now time.Time, lease time.Duration, limit int) []domain.WebhookDelivery {
+	SQL := `UPDATE webhook_deliveries SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = $2 AND next_attempt_at <= $3
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, now.Add(lease), domain.WebhookDeliveryPending, now, limit)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, now.Add(lease), domain.WebhookDeliveryPending, now, limit)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		helper.PanicIfError(err)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+func (r *WebhookDeliveryRepositoryImpl) UpdateResult(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) {
+	SQL := `UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4,
+		last_status_code = $5, last_error = $6, last_response = $7, delivered_at = $8 WHERE id = $9`
+	args := []interface{}{delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		delivery.LastStatusCode, delivery.LastError, delivery.LastResponse, delivery.DeliveredAt, delivery.Id}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+}
+
+func (r *WebhookDeliveryRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, deliveryId uuid.UUID) (domain.WebhookDelivery, error) {
+	SQL := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, deliveryId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, deliveryId)
+	}
+
+	delivery, err := scanWebhookDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return delivery, errors.New("webhook delivery not found")
+	}
+	return delivery, err
+}
+
+func (r *WebhookDeliveryRepositoryImpl) FindByWebhookId(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID, limit int) []domain.WebhookDelivery {
+	SQL := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, webhookId, limit)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, webhookId, limit)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		helper.PanicIfError(err)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+func scanWebhookDelivery(scanner rowScanner) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := scanner.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastAttemptAt, &delivery.LastStatusCode,
+		&delivery.LastError, &delivery.LastResponse, &delivery.DeliveredAt, &delivery.RedeliveryOf, &delivery.CreatedAt)
+	return delivery, err
+}
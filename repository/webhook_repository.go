@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type WebhookRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook
+	Update(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook
+	// Delete menghapus webhook beserta log delivery-nya
+	Delete(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID) error
+	FindById(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID) (domain.Webhook, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.Webhook
+	// FindSubscribed mengembalikan webhook aktif di workspace yang melanggan eventType
+	// untuk project tersebut (termasuk webhook tingkat workspace)
+	FindSubscribed(ctx context.Context, tx *sql.Tx, workspaceId uuid.UUID, projectId uuid.UUID, eventType string) []domain.Webhook
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type WebhookRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &WebhookRepositoryImpl{DB: db}
+}
+
+const webhookColumns = `id, workspace_id, project_id, url, secret, events, active, created_by, created_at, updated_at`
+
+func (r *WebhookRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook {
+	if webhook.Id == uuid.Nil {
+		webhook.Id = uuid.New()
+	}
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+	webhook.CreatedAt = helper.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+
+	SQL := `INSERT INTO webhooks(` + webhookColumns + `) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	args := []interface{}{webhook.Id, webhook.WorkspaceId, webhook.ProjectId, webhook.Url, webhook.Secret,
+		pq.Array(webhook.Events), webhook.Active, webhook.CreatedBy, webhook.CreatedAt, webhook.UpdatedAt}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return webhook
+}
+
+func (r *WebhookRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) domain.Webhook {
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+	webhook.UpdatedAt = helper.Now()
+
+	SQL := "UPDATE webhooks SET url = $1, events = $2, active = $3, updated_at = $4 WHERE id = $5"
+	args := []interface{}{webhook.Url, pq.Array(webhook.Events), webhook.Active, webhook.UpdatedAt, webhook.Id}
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, args...)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	return webhook
+}
+
+func (r *WebhookRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID) error {
+	statements := []string{
+		"DELETE FROM webhook_deliveries WHERE webhook_id = $1",
+		"DELETE FROM webhooks WHERE id = $1",
+	}
+	for _, SQL := range statements {
+		var err error
+		if tx != nil {
+			_, err = tx.ExecContext(ctx, SQL, webhookId)
+		} else {
+			_, err = r.DB.ExecContext(ctx, SQL, webhookId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID) (domain.Webhook, error) {
+	SQL := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, webhookId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, webhookId)
+	}
+
+	webhook, err := scanWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return webhook, errors.New("webhook not found")
+	}
+	return webhook, err
+}
+
+func (r *WebhookRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Webhook {
+	SQL := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at DESC`
+	return r.query(ctx, tx, SQL)
+}
+
+func (r *WebhookRepositoryImpl) FindSubscribed(ctx context.Context, tx *sql.Tx, workspaceId uuid.UUID, projectId uuid.UUID, eventType string) []domain.Webhook {
+	SQL := `SELECT ` + webhookColumns + ` FROM webhooks
+		WHERE active AND workspace_id = $1
+		AND (project_id IS NULL OR project_id = $2)
+		AND (cardinality(events) = 0 OR $3 = ANY(events))`
+	return r.query(ctx, tx, SQL, workspaceId, projectId, eventType)
+}
+
+func (r *WebhookRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.Webhook {
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, SQL, args...)
+	} else {
+		rows, err = r.DB.QueryContext(ctx, SQL, args...)
+	}
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		helper.PanicIfError(err)
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks
+}
+
+func scanWebhook(scanner rowScanner) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := scanner.Scan(&webhook.Id, &webhook.WorkspaceId, &webhook.ProjectId, &webhook.Url, &webhook.Secret,
+		pq.Array(&webhook.Events), &webhook.Active, &webhook.CreatedBy, &webhook.CreatedAt, &webhook.UpdatedAt)
+	return webhook, err
+}
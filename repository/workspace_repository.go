@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+type WorkspaceRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, workspace domain.Workspace) domain.Workspace
+	Update(ctx context.Context, tx *sql.Tx, workspace domain.Workspace) domain.Workspace
+	FindById(ctx context.Context, tx *sql.Tx, workspaceId uuid.UUID) (domain.Workspace, error)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+)
+
+type WorkspaceRepositoryImpl struct {
+	DB *sql.DB
+}
+
+func NewWorkspaceRepository(db *sql.DB) WorkspaceRepository {
+	return &WorkspaceRepositoryImpl{DB: db}
+}
+
+func (r *WorkspaceRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, workspace domain.Workspace) domain.Workspace {
+	if workspace.Id == uuid.Nil {
+		workspace.Id = uuid.New()
+	}
+	workspace.CreatedAt = helper.Now()
+	workspace.UpdatedAt = helper.Now()
+
+	SQL := "INSERT INTO workspaces(id, name, created_at, updated_at) VALUES($1, $2, $3, $4)"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, workspace.Id, workspace.Name, workspace.CreatedAt, workspace.UpdatedAt)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, workspace.Id, workspace.Name, workspace.CreatedAt, workspace.UpdatedAt)
+	}
+	helper.PanicIfError(err)
+	return workspace
+}
+
+func (r *WorkspaceRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, workspace domain.Workspace) domain.Workspace {
+	workspace.UpdatedAt = helper.Now()
+
+	SQL := "UPDATE workspaces SET name = $1, updated_at = $2 WHERE id = $3"
+
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, SQL, workspace.Name, workspace.UpdatedAt, workspace.Id)
+	} else {
+		_, err = r.DB.ExecContext(ctx, SQL, workspace.Name, workspace.UpdatedAt, workspace.Id)
+	}
+	helper.PanicIfError(err)
+	return workspace
+}
+
+func (r *WorkspaceRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, workspaceId uuid.UUID) (domain.Workspace, error) {
+	SQL := "SELECT id, name, created_at, updated_at FROM workspaces WHERE id = $1"
+
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, SQL, workspaceId)
+	} else {
+		row = r.DB.QueryRowContext(ctx, SQL, workspaceId)
+	}
+
+	var workspace domain.Workspace
+	err := row.Scan(&workspace.Id, &workspace.Name, &workspace.CreatedAt, &workspace.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return workspace, errors.New("workspace not found")
+	}
+	helper.PanicIfError(err)
+	return workspace, nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/ical"
+	"task-management/model/web"
+)
+
+// CalendarFeedService mengelola feed iCalendar (.ics) pribadi berisi tenggat
+// task dan milestone project, untuk dilanggan dari aplikasi kalender.
+type CalendarFeedService interface {
+	// Create membuat feed baru untuk user yang sedang login.
+	Create(ctx context.Context, request web.CalendarFeedCreateRequest) web.CalendarFeedResponse
+
+	// FindAll mengambil semua feed milik user yang sedang login, termasuk yang sudah di-revoke.
+	FindAll(ctx context.Context) []web.CalendarFeedResponse
+
+	// Revoke mematikan feed; URL-nya tidak bisa dipakai lagi.
+	Revoke(ctx context.Context, feedId uuid.UUID) web.CalendarFeedResponse
+
+	// Export menyusun isi feed lewat token feed, tanpa login.
+	Export(ctx context.Context, token string) *ical.Calendar
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/ical"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+const (
+	// calendarFeedHistory membatasi feed ke tenggat sejak 90 hari lalu agar tetap kecil
+	calendarFeedHistory = 90 * 24 * time.Hour
+	// calendarUidDomain adalah bagian domain UID; sengaja tetap (bukan dari
+	// PUBLIC_BASE_URL) agar UID tidak berubah saat alamat server berganti
+	calendarUidDomain = "task-management"
+	calendarProdId    = "-//Task Management//Calendar Feed//ID"
+)
+
+// calendarTodoStatus memetakan status task ke STATUS VTODO
+var calendarTodoStatus = map[string]string{
+	"todo":        "NEEDS-ACTION",
+	"in-progress": "IN-PROCESS",
+	"completed":   "COMPLETED",
+}
+
+// calendarPriority memetakan prioritas task ke PRIORITY (1 tertinggi, 9 terendah)
+var calendarPriority = map[string]string{
+	"high":   "1",
+	"medium": "5",
+	"low":    "9",
+}
+
+type CalendarFeedServiceImpl struct {
+	CalendarFeedRepository repository.CalendarFeedRepository
+	TaskRepository         repository.TaskRepository
+	ProjectRepository      repository.ProjectRepository
+	UserRepository         repository.UserRepository
+	ProfileRepository      repository.ProfileRepository
+	DB                     *sql.DB
+	Validator              *validator.Validate
+	// Secret untuk menandatangani token, BaseUrl untuk menyusun URL feed
+	Secret  []byte
+	BaseUrl string
+}
+
+func NewCalendarFeedService(
+	calendarFeedRepository repository.CalendarFeedRepository,
+	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+	userRepository repository.UserRepository,
+	profileRepository repository.ProfileRepository,
+	db *sql.DB,
+	validator *validator.Validate,
+	secret []byte,
+	baseUrl string,
+) CalendarFeedService {
+	return &CalendarFeedServiceImpl{
+		CalendarFeedRepository: calendarFeedRepository,
+		TaskRepository:         taskRepository,
+		ProjectRepository:      projectRepository,
+		UserRepository:         userRepository,
+		ProfileRepository:      profileRepository,
+		DB:                     db,
+		Validator:              validator,
+		Secret:                 secret,
+		BaseUrl:                baseUrl,
+	}
+}
+
+func (s *CalendarFeedServiceImpl) Create(ctx context.Context, request web.CalendarFeedCreateRequest) web.CalendarFeedResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	feed := domain.CalendarFeed{
+		UserId:    userId,
+		Component: request.Component,
+		Scope:     request.Scope,
+	}
+	if feed.Component == "" {
+		feed.Component = domain.CalendarComponentEvent
+	}
+	if feed.Scope == "" {
+		feed.Scope = domain.CalendarScopeAssigned
+	}
+	feed = s.CalendarFeedRepository.Save(ctx, tx, feed)
+
+	return s.toCalendarFeedResponse(feed)
+}
+
+func (s *CalendarFeedServiceImpl) FindAll(ctx context.Context) []web.CalendarFeedResponse {
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	feedResponses := []web.CalendarFeedResponse{}
+	for _, feed := range s.CalendarFeedRepository.FindByUserId(ctx, tx, userId) {
+		feedResponses = append(feedResponses, s.toCalendarFeedResponse(feed))
+	}
+	return feedResponses
+}
+
+func (s *CalendarFeedServiceImpl) Revoke(ctx context.Context, feedId uuid.UUID) web.CalendarFeedResponse {
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	feed, err := s.CalendarFeedRepository.FindById(ctx, tx, feedId)
+	if err != nil || feed.UserId != userId {
+		panic(exception.NewNotFoundError("calendar feed not found"))
+	}
+	feed = s.CalendarFeedRepository.Revoke(ctx, tx, feed)
+
+	return s.toCalendarFeedResponse(feed)
+}
+
+func (s *CalendarFeedServiceImpl) Export(ctx context.Context, token string) *ical.Calendar {
+	feed, user := s.findFeedOwner(ctx, token)
+
+	// Data dibaca di workspace pemilik feed, dengan akses project miliknya sendiri
+	ctx = helper.ContextWithUserId(helper.ContextWithWorkspaceId(ctx, user.WorkspaceId), user.Id)
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	location := helper.LoadLocation(profileTimezone(ctx, tx, s.ProfileRepository, user.Id))
+	since := helper.Now().Add(-calendarFeedHistory)
+
+	calendar := &ical.Calendar{
+		ProdId:   calendarProdId,
+		Name:     "Task Management (" + user.FullName + ")",
+		Timezone: location.String(),
+	}
+
+	projects := map[uuid.UUID]domain.Project{}
+	var projectIds []uuid.UUID
+	for _, project := range s.ProjectRepository.FindByUserId(ctx, tx, user.Id) {
+		projects[project.Id] = project
+		projectIds = append(projectIds, project.Id)
+	}
+
+	var tasks []domain.Task
+	if feed.Scope == domain.CalendarScopeWatching {
+		tasks, err = s.TaskRepository.FindWatchedByUserId(ctx, tx, user.Id)
+	} else {
+		tasks, err = s.TaskRepository.FindDueByProjectIds(ctx, tx, projectIds, since)
+	}
+	helper.PanicIfError(err)
+
+	for _, task := range tasks {
+		project, ok := projects[task.ProjectId]
+		if !ok || task.DueDate == nil || task.DueDate.Before(since) {
+			continue
+		}
+		if feed.Scope == domain.CalendarScopeAssigned && (task.AssigneeId == nil || *task.AssigneeId != user.Id) {
+			continue
+		}
+		calendar.Components = append(calendar.Components, calendarTaskComponent(feed, task, project, location))
+	}
+
+	// Milestone project: tanggal mulai (hanya sebagai acara) dan tenggat
+	for _, projectId := range projectIds {
+		project := projects[projectId]
+		if project.Status == domain.ProjectStatusArchived {
+			continue
+		}
+		if project.StartDate != nil && !project.StartDate.Before(since) && feed.Component == domain.CalendarComponentEvent {
+			calendar.Components = append(calendar.Components, calendarMilestoneComponent(feed, project, "start", *project.StartDate, location))
+		}
+		if project.DueDate != nil && !project.DueDate.Before(since) {
+			calendar.Components = append(calendar.Components, calendarMilestoneComponent(feed, project, "due", *project.DueDate, location))
+		}
+	}
+
+	return calendar
+}
+
+// findFeedOwner memverifikasi token lalu mengambil feed dan pemiliknya.
+// Request publik tidak punya workspace, jadi dibaca dengan context sistem;
+// token yang valid dan belum di-revoke sudah cukup sebagai izin.
+func (s *CalendarFeedServiceImpl) findFeedOwner(ctx context.Context, token string) (domain.CalendarFeed, domain.User) {
+	feedId, err := helper.ParseCalendarToken(s.Secret, token)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	ctx = helper.ContextWithSystem(ctx)
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	feed, err := s.CalendarFeedRepository.FindById(ctx, tx, feedId)
+	if err != nil || feed.RevokedAt != nil {
+		panic(exception.NewNotFoundError(helper.ErrInvalidCalendarToken.Error()))
+	}
+	user, err := s.UserRepository.FindById(ctx, tx, feed.UserId)
+	if err != nil {
+		panic(exception.NewNotFoundError(helper.ErrInvalidCalendarToken.Error()))
+	}
+	return feed, user
+}
+
+func (s *CalendarFeedServiceImpl) toCalendarFeedResponse(feed domain.CalendarFeed) web.CalendarFeedResponse {
+	token := helper.SignCalendarToken(s.Secret, feed.Id)
+	url := s.BaseUrl + "/public/calendar/" + token + ".ics"
+	return web.CalendarFeedResponse{
+		Id:        feed.Id,
+		Component: feed.Component,
+		Scope:     feed.Scope,
+		Token:     token,
+		Url:       url,
+		WebcalUrl: "webcal://" + strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://"),
+		RevokedAt: feed.RevokedAt,
+		CreatedAt: feed.CreatedAt,
+	}
+}
+
+// calendarTaskComponent menyusun VEVENT (tenggat di DTSTART) atau VTODO (di DUE) untuk task.
+// UID hanya bergantung pada ID task sehingga klien kalender memperbarui item yang sama.
+func calendarTaskComponent(feed domain.CalendarFeed, task domain.Task, project domain.Project, location *time.Location) *ical.Component {
+	var component *ical.Component
+	if feed.Component == domain.CalendarComponentTodo {
+		component = ical.NewComponent("VTODO")
+	} else {
+		component = ical.NewComponent("VEVENT")
+	}
+	component.Add("UID", "task-"+task.Id.String()+"@"+calendarUidDomain)
+	// Tanpa METHOD, DTSTAMP berarti waktu revisi terakhir (RFC 5545 3.8.7.2)
+	component.AddDateTime("DTSTAMP", task.UpdatedAt)
+	component.AddDateTime("CREATED", task.CreatedAt)
+	component.AddDateTime("LAST-MODIFIED", task.UpdatedAt)
+	component.AddText("SUMMARY", task.Title)
+
+	description := []string{"Project: " + project.Name, "Status: " + task.Status}
+	if task.Priority != "" {
+		description = append(description, "Prioritas: "+task.Priority)
+	}
+	if task.Deliverable != "" {
+		description = append(description, "Deliverable: "+task.Deliverable)
+	}
+	if task.Progress != "" {
+		description = append(description, "Progress: "+task.Progress)
+	}
+	component.AddText("DESCRIPTION", strings.Join(description, "\n"))
+	if len(task.Labels) > 0 {
+		component.AddTextList("CATEGORIES", task.Labels)
+	}
+
+	if feed.Component == domain.CalendarComponentTodo {
+		addCalendarDate(component, "DUE", *task.DueDate, location)
+		status, ok := calendarTodoStatus[task.Status]
+		if !ok {
+			status = "NEEDS-ACTION"
+		}
+		component.Add("STATUS", status)
+		if status == "COMPLETED" {
+			component.Add("PERCENT-COMPLETE", "100")
+		}
+		if priority, ok := calendarPriority[task.Priority]; ok {
+			component.Add("PRIORITY", priority)
+		}
+	} else {
+		addCalendarDate(component, "DTSTART", *task.DueDate, location)
+		// Tenggat tidak membuat user terlihat sibuk di jadwal
+		component.Add("TRANSP", "TRANSPARENT")
+	}
+	return component
+}
+
+// calendarMilestoneComponent menyusun item untuk tanggal mulai ("start") atau tenggat ("due") project
+func calendarMilestoneComponent(feed domain.CalendarFeed, project domain.Project, milestone string, date time.Time, location *time.Location) *ical.Component {
+	summary := "Tenggat project: " + project.Name
+	if milestone == "start" {
+		summary = "Project dimulai: " + project.Name
+	}
+
+	var component *ical.Component
+	if feed.Component == domain.CalendarComponentTodo {
+		component = ical.NewComponent("VTODO")
+	} else {
+		component = ical.NewComponent("VEVENT")
+	}
+	component.Add("UID", "project-"+project.Id.String()+"-"+milestone+"@"+calendarUidDomain)
+	component.AddDateTime("DTSTAMP", project.UpdatedAt)
+	component.AddDateTime("LAST-MODIFIED", project.UpdatedAt)
+	component.AddText("SUMMARY", summary)
+	if project.Description != "" {
+		component.AddText("DESCRIPTION", project.Description)
+	}
+
+	if feed.Component == domain.CalendarComponentTodo {
+		addCalendarDate(component, "DUE", date, location)
+		if project.Status == domain.ProjectStatusCompleted {
+			component.Add("STATUS", "COMPLETED")
+		} else {
+			component.Add("STATUS", "NEEDS-ACTION")
+		}
+	} else {
+		addCalendarDate(component, "DTSTART", date, location)
+		component.Add("TRANSP", "TRANSPARENT")
+	}
+	return component
+}
+
+// addCalendarDate menulis tanggal tanpa jam (tengah malam di zona waktu user,
+// atau di UTC) sebagai DATE agar tidak bergeser hari di klien dengan zona
+// waktu lain; selain itu sebagai DATE-TIME UTC.
+func addCalendarDate(component *ical.Component, name string, t time.Time, location *time.Location) {
+	if local := t.In(location); isMidnight(local) {
+		component.AddDate(name, local)
+		return
+	}
+	if utc := t.UTC(); isMidnight(utc) {
+		component.AddDate(name, utc)
+		return
+	}
+	component.AddDateTime(name, t)
+}
+
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+}
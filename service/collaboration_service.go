@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"task-management/event"
+	"task-management/model/web"
+)
+
+// CollaborationService menangani koneksi WebSocket board: subscribe ke project,
+// presence, dan edit optimistis yang dibalas ack/reject.
+type CollaborationService interface {
+	// Connect mendaftarkan koneksi baru untuk user di context.
+	Connect(ctx context.Context) *event.Peer
+
+	// Disconnect mengeluarkan koneksi dari semua project.
+	Disconnect(peer *event.Peer)
+
+	// Handle memproses satu pesan dari client; balasan dikirim lewat peer.Send.
+	Handle(ctx context.Context, peer *event.Peer, request web.CollaborationRequest)
+
+	// SendError mengirim pesan error ke satu koneksi.
+	SendError(peer *event.Peer, ref string, message string)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+type CollaborationServiceImpl struct {
+	Hub                     *event.Hub
+	TaskService             TaskService
+	ProjectRepository       repository.ProjectRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	DB                      *sql.DB
+}
+
+func NewCollaborationService(
+	hub *event.Hub,
+	taskService TaskService,
+	projectRepository repository.ProjectRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	db *sql.DB,
+) CollaborationService {
+	return &CollaborationServiceImpl{
+		Hub:                     hub,
+		TaskService:             taskService,
+		ProjectRepository:       projectRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		DB:                      db,
+	}
+}
+
+func (s *CollaborationServiceImpl) Connect(ctx context.Context) *event.Peer {
+	userId, ok := helper.UserIdFromContext(ctx)
+	if !ok {
+		panic(exception.NewForbiddenError("user not found in context"))
+	}
+	return s.Hub.Connect(userId)
+}
+
+func (s *CollaborationServiceImpl) Disconnect(peer *event.Peer) {
+	s.Hub.Disconnect(peer)
+}
+
+func (s *CollaborationServiceImpl) Handle(ctx context.Context, peer *event.Peer, request web.CollaborationRequest) {
+	// Error dari service (panic) dibalas sebagai pesan error, koneksi tetap hidup
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.SendError(peer, request.Ref, panicMessage(recovered))
+		}
+	}()
+
+	switch request.Type {
+	case "subscribe":
+		s.subscribe(ctx, peer, request)
+	case "unsubscribe":
+		projectId := requireProjectId(request)
+		s.Hub.Leave(peer, projectId)
+		s.Hub.SendTo(peer, web.CollaborationMessage{Type: "unsubscribed", Ref: request.Ref, ProjectId: &projectId})
+	case "view":
+		s.view(ctx, peer, request)
+	case "edit":
+		s.edit(ctx, peer, request)
+	default:
+		panic(exception.NewBadRequestError(fmt.Sprintf("unknown message type %q", request.Type)))
+	}
+}
+
+func (s *CollaborationServiceImpl) SendError(peer *event.Peer, ref string, message string) {
+	s.Hub.SendTo(peer, web.CollaborationMessage{Type: "error", Ref: ref, Error: message})
+}
+
+func (s *CollaborationServiceImpl) subscribe(ctx context.Context, peer *event.Peer, request web.CollaborationRequest) {
+	projectId := requireProjectId(request)
+	s.authorizeViewer(ctx, projectId)
+
+	presence := s.Hub.Join(peer, projectId)
+	s.Hub.SendTo(peer, web.CollaborationMessage{Type: "subscribed", Ref: request.Ref, ProjectId: &projectId, Presence: presence})
+}
+
+// authorizeViewer memakai transaksi singkat; koneksi WebSocket tidak menahan transaksi
+func (s *CollaborationServiceImpl) authorizeViewer(ctx context.Context, projectId uuid.UUID) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+}
+
+func (s *CollaborationServiceImpl) view(ctx context.Context, peer *event.Peer, request web.CollaborationRequest) {
+	projectId := requireProjectId(request)
+	if request.TaskId != nil {
+		// Task harus ada di project yang sama agar presence tidak bisa dipalsukan
+		task := s.TaskService.FindById(ctx, *request.TaskId)
+		if task.ProjectId != projectId {
+			panic(exception.NewBadRequestError("task does not belong to project " + projectId.String()))
+		}
+	}
+	if !s.Hub.View(peer, projectId, request.TaskId) {
+		panic(exception.NewConflictError("subscribe to project " + projectId.String() + " first"))
+	}
+}
+
+// edit menyebarkan perubahan ke client lain sebelum disimpan (optimistis), lalu
+// mengirim ack ke pengirim dan edit.applied ke yang lain, atau reject/edit.rejected
+// agar semua client mengembalikan tampilan.
+func (s *CollaborationServiceImpl) edit(ctx context.Context, peer *event.Peer, request web.CollaborationRequest) {
+	if request.TaskId == nil || request.Patch == nil {
+		panic(exception.NewBadRequestError("task_id and patch are required"))
+	}
+	task := s.TaskService.FindById(ctx, *request.TaskId)
+	if !s.Hub.Joined(peer, task.ProjectId) {
+		panic(exception.NewConflictError("subscribe to project " + task.ProjectId.String() + " first"))
+	}
+
+	userId := peer.UserId
+	s.Hub.Broadcast(task.ProjectId, web.CollaborationMessage{
+		Type:      "edit",
+		Ref:       request.Ref,
+		ProjectId: &task.ProjectId,
+		TaskId:    &task.Id,
+		UserId:    &userId,
+		Patch:     request.Patch,
+	}, peer)
+
+	updated, err := s.updateTask(ctx, task, *request.Patch)
+	if err != nil {
+		s.Hub.SendTo(peer, web.CollaborationMessage{Type: "reject", Ref: request.Ref, TaskId: &task.Id, Task: &task, Error: err.Error()})
+		s.Hub.Broadcast(task.ProjectId, web.CollaborationMessage{Type: "edit.rejected", Ref: request.Ref, TaskId: &task.Id, UserId: &userId, Task: &task}, peer)
+		return
+	}
+	s.Hub.SendTo(peer, web.CollaborationMessage{Type: "ack", Ref: request.Ref, TaskId: &task.Id, Task: &updated})
+	s.Hub.Broadcast(task.ProjectId, web.CollaborationMessage{Type: "edit.applied", Ref: request.Ref, TaskId: &task.Id, UserId: &userId, Task: &updated}, peer)
+}
+
+// updateTask memanggil TaskService.Update dan mengubah panic menjadi error
+func (s *CollaborationServiceImpl) updateTask(ctx context.Context, task web.TaskResponse, patch web.TaskUpdateRequest) (updated web.TaskResponse, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%s", panicMessage(recovered))
+		}
+	}()
+	return s.TaskService.Update(ctx, task.Id, patch), nil
+}
+
+func requireProjectId(request web.CollaborationRequest) uuid.UUID {
+	if request.ProjectId == nil {
+		panic(exception.NewBadRequestError("project_id is required"))
+	}
+	return *request.ProjectId
+}
+
+// panicMessage mengambil pesan dari panic service (exception.XxxError, error validasi, error biasa)
+func panicMessage(recovered interface{}) string {
+	switch err := recovered.(type) {
+	case exception.BadRequestError:
+		return err.Error
+	case exception.ForbiddenError:
+		return err.Error
+	case exception.NotFoundError:
+		return err.Error
+	case exception.ConflictError:
+		return err.Error
+	case validator.ValidationErrors:
+		return err.Error()
+	case error:
+		return err.Error()
+	default:
+		return fmt.Sprint(recovered)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"task-management/event"
+	"task-management/model/domain"
+)
+
+// publishTaskChange mempublikasikan event perubahan task dengan aturan yang sama
+// seperti recordTaskChange: before nil berarti task baru, after nil berarti dihapus.
+// Perubahan status juga menghasilkan task.status_changed setelah task.updated.
+func publishTaskChange(ctx context.Context, tx *sql.Tx, bus *event.Bus, before *domain.Task, after *domain.Task) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		bus.Publish(ctx, tx, domain.TaskEvent{Name: domain.EventTaskCreated, After: after})
+	case after == nil:
+		bus.Publish(ctx, tx, domain.TaskEvent{Name: domain.EventTaskDeleted, Before: before})
+	default:
+		bus.Publish(ctx, tx, domain.TaskEvent{Name: domain.EventTaskUpdated, Before: before, After: after})
+		if before.Status != after.Status {
+			bus.Publish(ctx, tx, domain.TaskEvent{Name: domain.EventTaskStatusChanged, Before: before, After: after})
+		}
+	}
+}
+
+// publishProjectChange sama seperti publishTaskChange untuk project
+func publishProjectChange(ctx context.Context, tx *sql.Tx, bus *event.Bus, before *domain.Project, after *domain.Project) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		bus.Publish(ctx, tx, domain.ProjectEvent{Name: domain.EventProjectCreated, After: after})
+	case after == nil:
+		bus.Publish(ctx, tx, domain.ProjectEvent{Name: domain.EventProjectDeleted, Before: before})
+	default:
+		bus.Publish(ctx, tx, domain.ProjectEvent{Name: domain.EventProjectUpdated, Before: before, After: after})
+		if before.Status != after.Status {
+			bus.Publish(ctx, tx, domain.ProjectEvent{Name: domain.EventProjectStatusChanged, Before: before, After: after})
+		}
+	}
+}
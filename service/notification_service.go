@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"task-management/event"
+	"task-management/model/web"
+)
+
+// NotificationService memberi tahu user tentang task yang relevan: ditugaskan,
+// disebut (@email), status berubah dan tenggat mendekat. Semua notifikasi masuk
+// inbox in-app; penugasan dan mention juga dikirim lewat email sesuai preference.
+type NotificationService interface {
+	// FindAll mengambil inbox user yang sedang login beserta jumlah belum dibaca.
+	FindAll(ctx context.Context, request web.NotificationListRequest) web.NotificationListResponse
+
+	// MarkRead menandai notifikasi milik user sebagai sudah (read=true) atau belum dibaca.
+	MarkRead(ctx context.Context, request web.NotificationMarkRequest, read bool) web.NotificationCountResponse
+
+	MarkAllRead(ctx context.Context) web.NotificationCountResponse
+
+	// FindPreference mengambil preference email user yang sedang login.
+	FindPreference(ctx context.Context) web.NotificationPreferenceResponse
+
+	UpdatePreference(ctx context.Context, request web.NotificationPreferenceUpdateRequest) web.NotificationPreferenceResponse
+
+	// FindUnsubscribe memverifikasi token berhenti berlangganan dan mengembalikan email user.
+	FindUnsubscribe(ctx context.Context, token string) string
+
+	// Unsubscribe mematikan email notifikasi user pemilik token (tanpa login).
+	Unsubscribe(ctx context.Context, token string) string
+
+	// NotificationService juga sink outbox: notifikasi dibuat dari perubahan task yang sudah ter-commit.
+	event.Sink
+
+	// DeliverEmails mengirim email yang sudah jatuh tempo (dijalankan job terjadwal).
+	DeliverEmails(ctx context.Context)
+
+	// SendDigests mengirim digest harian ke user yang sudah melewati jam digest-nya.
+	SendDigests(ctx context.Context)
+
+	// NotifyDueSoon membuat notifikasi untuk assignee task yang segera jatuh tempo.
+	NotifyDueSoon(ctx context.Context)
+
+	// Prune menghapus notifikasi inbox yang sudah lama.
+	Prune(ctx context.Context)
+}
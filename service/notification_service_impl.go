@@ -0,0 +1,742 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/mail"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+const (
+	// emailMaxAttempts adalah jumlah percobaan kirim sebelum email dianggap gagal
+	emailMaxAttempts = 8
+	// emailBaseBackoff dikali dua setiap percobaan gagal, maksimal emailMaxBackoff
+	emailBaseBackoff = time.Minute
+	emailMaxBackoff  = 6 * time.Hour
+	// emailLease menahan email yang sedang dikirim agar tidak diambil worker lain
+	emailLease = 5 * time.Minute
+	// emailBatchSize adalah jumlah email yang diproses per putaran job
+	emailBatchSize = 50
+	// notificationExcerptLength membatasi kutipan teks yang menyebut user
+	notificationExcerptLength = 200
+	// notificationPageSize adalah jumlah notifikasi default per halaman inbox
+	notificationPageSize = 50
+	// notificationDueSoonWindow adalah jarak tenggat yang dianggap segera jatuh tempo
+	notificationDueSoonWindow = 24 * time.Hour
+	// Notifikasi terbaca disimpan 30 hari, yang belum dibaca 90 hari
+	notificationReadRetention = 30 * 24 * time.Hour
+	notificationRetention     = 90 * 24 * time.Hour
+)
+
+// mentionPattern mencari mention berbentuk @email, mis. "@ani@example.com"
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+type NotificationServiceImpl struct {
+	NotificationRepository           repository.NotificationRepository
+	NotificationPreferenceRepository repository.NotificationPreferenceRepository
+	EmailMessageRepository           repository.EmailMessageRepository
+	EmailDigestItemRepository        repository.EmailDigestItemRepository
+	TaskRepository                   repository.TaskRepository
+	UserRepository                   repository.UserRepository
+	ProfileRepository                repository.ProfileRepository
+	ProjectRepository                repository.ProjectRepository
+	ProjectMemberRepository          repository.ProjectMemberRepository
+	TaskWatcherRepository            repository.TaskWatcherRepository
+	DB                               *sql.DB
+	Validator                        *validator.Validate
+	Mailer                           mail.Mailer
+	Templates                        *mail.Templates
+	UnsubscribeSecret                []byte
+	PublicBaseUrl                    string
+}
+
+func NewNotificationService(
+	notificationRepository repository.NotificationRepository,
+	notificationPreferenceRepository repository.NotificationPreferenceRepository,
+	emailMessageRepository repository.EmailMessageRepository,
+	emailDigestItemRepository repository.EmailDigestItemRepository,
+	taskRepository repository.TaskRepository,
+	userRepository repository.UserRepository,
+	profileRepository repository.ProfileRepository,
+	projectRepository repository.ProjectRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	taskWatcherRepository repository.TaskWatcherRepository,
+	db *sql.DB,
+	validator *validator.Validate,
+	mailer mail.Mailer,
+	templates *mail.Templates,
+	unsubscribeSecret []byte,
+	publicBaseUrl string,
+) NotificationService {
+	return &NotificationServiceImpl{
+		NotificationRepository:           notificationRepository,
+		NotificationPreferenceRepository: notificationPreferenceRepository,
+		EmailMessageRepository:           emailMessageRepository,
+		EmailDigestItemRepository:        emailDigestItemRepository,
+		TaskRepository:                   taskRepository,
+		UserRepository:                   userRepository,
+		ProfileRepository:                profileRepository,
+		ProjectRepository:                projectRepository,
+		ProjectMemberRepository:          projectMemberRepository,
+		TaskWatcherRepository:            taskWatcherRepository,
+		DB:                               db,
+		Validator:                        validator,
+		Mailer:                           mailer,
+		Templates:                        templates,
+		UnsubscribeSecret:                unsubscribeSecret,
+		PublicBaseUrl:                    strings.TrimRight(publicBaseUrl, "/"),
+	}
+}
+
+func (s *NotificationServiceImpl) FindAll(ctx context.Context, request web.NotificationListRequest) web.NotificationListResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	userId := currentUserId(ctx)
+	limit := request.Limit
+	if limit == 0 {
+		limit = notificationPageSize
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	// Ambil satu lebih banyak untuk mengetahui apakah masih ada halaman berikutnya
+	notifications := s.NotificationRepository.FindByUserId(ctx, tx, userId, request.UnreadOnly, request.Before, limit+1)
+	response := web.NotificationListResponse{
+		UnreadCount:   s.NotificationRepository.CountUnread(ctx, tx, userId),
+		Notifications: []web.NotificationResponse{},
+	}
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		nextBefore := notifications[limit-1].CreatedAt
+		response.NextBefore = &nextBefore
+	}
+	for _, notification := range notifications {
+		response.Notifications = append(response.Notifications, toNotificationResponse(notification))
+	}
+	return response
+}
+
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, request web.NotificationMarkRequest, read bool) web.NotificationCountResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	// Notifikasi milik user lain diabaikan (difilter user_id di query)
+	var readAt *time.Time
+	if read {
+		now := helper.Now()
+		readAt = &now
+	}
+	s.NotificationRepository.MarkRead(ctx, tx, userId, request.Ids, readAt)
+
+	return web.NotificationCountResponse{UnreadCount: s.NotificationRepository.CountUnread(ctx, tx, userId)}
+}
+
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context) web.NotificationCountResponse {
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	s.NotificationRepository.MarkAllRead(ctx, tx, userId, helper.Now())
+
+	return web.NotificationCountResponse{UnreadCount: 0}
+}
+
+func (s *NotificationServiceImpl) FindPreference(ctx context.Context) web.NotificationPreferenceResponse {
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	preference := s.findPreference(ctx, tx, userId)
+	return toNotificationPreferenceResponse(preference, profileTimezone(ctx, tx, s.ProfileRepository, userId))
+}
+
+func (s *NotificationServiceImpl) UpdatePreference(ctx context.Context, request web.NotificationPreferenceUpdateRequest) web.NotificationPreferenceResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	userId := currentUserId(ctx)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	preference := s.findPreference(ctx, tx, userId)
+	preference.EmailMode = request.EmailMode
+	if request.DigestHour != nil {
+		preference.DigestHour = *request.DigestHour
+	}
+	preference = s.NotificationPreferenceRepository.Save(ctx, tx, preference)
+
+	return toNotificationPreferenceResponse(preference, profileTimezone(ctx, tx, s.ProfileRepository, userId))
+}
+
+func (s *NotificationServiceImpl) FindUnsubscribe(ctx context.Context, token string) string {
+	// Request publik tidak punya workspace; token yang valid cukup sebagai izin
+	ctx = helper.ContextWithSystem(ctx)
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return s.findUnsubscribeUser(ctx, tx, token).Email
+}
+
+func (s *NotificationServiceImpl) Unsubscribe(ctx context.Context, token string) string {
+	ctx = helper.ContextWithSystem(ctx)
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user := s.findUnsubscribeUser(ctx, tx, token)
+	preference := s.findPreference(ctx, tx, user.Id)
+	preference.EmailMode = domain.EmailModeOff
+	s.NotificationPreferenceRepository.Save(ctx, tx, preference)
+
+	return user.Email
+}
+
+func (s *NotificationServiceImpl) findUnsubscribeUser(ctx context.Context, tx *sql.Tx, token string) domain.User {
+	userId, err := helper.ParseUnsubscribeToken(s.UnsubscribeSecret, token)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	user, err := s.UserRepository.FindById(ctx, tx, userId)
+	if err != nil {
+		panic(exception.NewNotFoundError(helper.ErrInvalidUnsubscribeToken.Error()))
+	}
+	return user
+}
+
+func (s *NotificationServiceImpl) Name() string { return "notification" }
+
+// pendingNotification adalah satu notifikasi untuk satu penerima
+type pendingNotification struct {
+	UserId  uuid.UUID
+	Kind    string
+	Excerpt string
+}
+
+// Handle membuat notifikasi dari event task. Dipanggil di transaksi relay,
+// jadi inbox, email dan item digest tersimpan atomik bersama status event.
+func (s *NotificationServiceImpl) Handle(ctx context.Context, tx *sql.Tx, outboxEvent domain.OutboxEvent) error {
+	// task.status_changed selalu didahului task.updated dengan data yang sama,
+	// jadi cukup task.updated agar notifikasi tidak ganda
+	switch outboxEvent.EventType {
+	case domain.EventTaskCreated, domain.EventTaskUpdated:
+	default:
+		return nil
+	}
+	var message struct {
+		ActorId *uuid.UUID        `json:"actor_id"`
+		Data    web.TaskEventData `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(outboxEvent.Payload), &message); err != nil {
+		return err
+	}
+
+	task, previous := message.Data.Task, message.Data.Previous
+	project, err := s.ProjectRepository.FindById(ctx, tx, task.ProjectId)
+	if err != nil {
+		return nil
+	}
+
+	notifications := s.taskNotifications(ctx, tx, project, task, previous)
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	actorName := ""
+	if message.ActorId != nil {
+		if actor, err := s.UserRepository.FindById(ctx, tx, *message.ActorId); err == nil {
+			actorName = actor.FullName
+		}
+	}
+
+	for _, notification := range notifications {
+		// Perubahan yang dilakukan user sendiri tidak perlu diberitahukan
+		if message.ActorId != nil && notification.UserId == *message.ActorId {
+			continue
+		}
+		if err := s.notify(ctx, tx, notification, project, task, previous, message.ActorId, actorName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taskNotifications menentukan penerima: assignee baru, user yang baru disebut,
+// lalu watcher task jika status atau field lain berubah. Satu user hanya
+// menerima satu notifikasi per event, dengan urutan prioritas tersebut.
+func (s *NotificationServiceImpl) taskNotifications(ctx context.Context, tx *sql.Tx, project domain.Project, task web.TaskResponse, previous *web.TaskResponse) []pendingNotification {
+	var notifications []pendingNotification
+	notified := map[uuid.UUID]bool{}
+
+	if task.AssigneeId != nil && (previous == nil || previous.AssigneeId == nil || *previous.AssigneeId != *task.AssigneeId) {
+		notifications = append(notifications, pendingNotification{UserId: *task.AssigneeId, Kind: domain.NotificationAssigned})
+		notified[*task.AssigneeId] = true
+	}
+
+	previousMentions := map[string]bool{}
+	if previous != nil {
+		for _, text := range taskTexts(*previous) {
+			for _, email := range findMentions(text) {
+				previousMentions[email] = true
+			}
+		}
+	}
+	for _, text := range taskTexts(task) {
+		for _, email := range findMentions(text) {
+			if previousMentions[email] {
+				continue
+			}
+			previousMentions[email] = true
+
+			// Hanya user di workspace yang sama dan punya akses ke project
+			user, err := s.UserRepository.FindByEmail(ctx, tx, email)
+			if err != nil || user.Id == uuid.Nil || user.WorkspaceId != project.WorkspaceId || notified[user.Id] {
+				continue
+			}
+			if projectRole(ctx, tx, s.ProjectMemberRepository, project, user.Id) == "" {
+				continue
+			}
+			notifications = append(notifications, pendingNotification{UserId: user.Id, Kind: domain.NotificationMentioned, Excerpt: excerpt(text)})
+			notified[user.Id] = true
+		}
+	}
+
+	if previous == nil {
+		return notifications
+	}
+	kind := domain.NotificationTaskUpdated
+	if previous.Status != task.Status {
+		kind = domain.NotificationStatusChanged
+	} else if len(changedTaskFields(*previous, task)) == 0 {
+		return notifications
+	}
+	for _, userId := range s.taskWatchers(ctx, tx, project, task) {
+		if !notified[userId] {
+			notifications = append(notifications, pendingNotification{UserId: userId, Kind: kind})
+			notified[userId] = true
+		}
+	}
+	return notifications
+}
+
+// taskWatchers adalah watcher task yang masih punya akses ke project
+func (s *NotificationServiceImpl) taskWatchers(ctx context.Context, tx *sql.Tx, project domain.Project, task web.TaskResponse) []uuid.UUID {
+	var watchers []uuid.UUID
+	for _, userId := range s.TaskWatcherRepository.FindUserIdsByTaskId(ctx, tx, task.Id) {
+		if projectRole(ctx, tx, s.ProjectMemberRepository, project, userId) != "" {
+			watchers = append(watchers, userId)
+		}
+	}
+	return watchers
+}
+
+// notify menyimpan notifikasi ke inbox penerima, lalu mengirim email untuk
+// penugasan dan mention sesuai preference penerima
+func (s *NotificationServiceImpl) notify(ctx context.Context, tx *sql.Tx, notification pendingNotification, project domain.Project, task web.TaskResponse, previous *web.TaskResponse, actorId *uuid.UUID, actorName string) error {
+	s.NotificationRepository.Save(ctx, tx, domain.Notification{
+		UserId:    notification.UserId,
+		Kind:      notification.Kind,
+		ProjectId: &project.Id,
+		TaskId:    &task.Id,
+		ActorId:   actorId,
+		Title:     task.Title,
+		Message:   taskNotificationMessage(notification, actorName, task, previous),
+	})
+	if notification.Kind != domain.NotificationAssigned && notification.Kind != domain.NotificationMentioned {
+		return nil
+	}
+
+	preference := s.findPreference(ctx, tx, notification.UserId)
+
+	switch preference.EmailMode {
+	case domain.EmailModeDaily:
+		s.EmailDigestItemRepository.Save(ctx, tx, domain.EmailDigestItem{
+			UserId:      notification.UserId,
+			Kind:        notification.Kind,
+			TaskId:      task.Id,
+			TaskTitle:   task.Title,
+			ProjectId:   project.Id,
+			ProjectName: project.Name,
+			ActorName:   actorName,
+			Excerpt:     notification.Excerpt,
+		})
+		return nil
+	case domain.EmailModeInstant:
+	default:
+		return nil
+	}
+
+	user, err := s.UserRepository.FindById(ctx, tx, notification.UserId)
+	if err != nil {
+		return nil
+	}
+	data := mail.NotificationData{
+		RecipientName:  user.FullName,
+		ActorName:      actorName,
+		TaskTitle:      task.Title,
+		ProjectName:    project.Name,
+		Status:         task.Status,
+		Excerpt:        notification.Excerpt,
+		UnsubscribeUrl: s.unsubscribeUrl(user.Id),
+	}
+	if task.DueDate != nil {
+		location := helper.LoadLocation(profileTimezone(ctx, tx, s.ProfileRepository, user.Id))
+		data.DueDate = task.DueDate.In(location).Format("2006-01-02")
+	}
+
+	message, err := s.Templates.Render(notification.Kind, data)
+	if err != nil {
+		return err
+	}
+	s.enqueue(ctx, tx, user, message)
+	return nil
+}
+
+func (s *NotificationServiceImpl) enqueue(ctx context.Context, tx *sql.Tx, user domain.User, message mail.Message) {
+	s.EmailMessageRepository.Save(ctx, tx, domain.EmailMessage{
+		UserId:         user.Id,
+		ToAddress:      user.Email,
+		Subject:        message.Subject,
+		HtmlBody:       message.HTML,
+		TextBody:       message.Text,
+		UnsubscribeUrl: s.unsubscribeUrl(user.Id),
+	})
+}
+
+func (s *NotificationServiceImpl) DeliverEmails(ctx context.Context) {
+	// Klaim di transaksi singkat; koneksi SMTP dibuka di luar transaksi
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	messages := s.EmailMessageRepository.ClaimDue(ctx, tx, helper.Now(), emailLease, emailBatchSize)
+	helper.CommitOrRollback(tx)
+
+	for _, message := range messages {
+		s.deliver(ctx, message)
+	}
+}
+
+// deliver mengirim satu email lalu menyimpan hasilnya; email gagal dijadwalkan ulang
+func (s *NotificationServiceImpl) deliver(ctx context.Context, message domain.EmailMessage) {
+	now := helper.Now()
+	message.Attempts++
+	message.LastAttemptAt = &now
+
+	err := s.Mailer.Send(ctx, mail.Message{
+		To:             message.ToAddress,
+		Subject:        message.Subject,
+		HTML:           message.HtmlBody,
+		Text:           message.TextBody,
+		UnsubscribeUrl: message.UnsubscribeUrl,
+	})
+	if err == nil {
+		message.Status = domain.EmailSent
+		message.SentAt = &now
+		message.LastError = ""
+	} else {
+		message.LastError = err.Error()
+		if message.Attempts >= emailMaxAttempts {
+			message.Status = domain.EmailFailed
+		} else {
+			message.NextAttemptAt = now.Add(helper.Backoff(emailBaseBackoff, emailMaxBackoff, message.Attempts))
+		}
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+	s.EmailMessageRepository.UpdateResult(ctx, tx, message)
+
+	if message.Status == domain.EmailFailed {
+		fmt.Printf("⚠️ Email %s to %s failed after %d attempt(s): %s\n", message.Id, message.ToAddress, message.Attempts, message.LastError)
+	}
+}
+
+func (s *NotificationServiceImpl) SendDigests(ctx context.Context) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	userIds := s.EmailDigestItemRepository.FindPendingUserIds(ctx, tx)
+	helper.CommitOrRollback(tx)
+
+	now := helper.Now()
+	for _, userId := range userIds {
+		s.sendDigest(ctx, userId, now)
+	}
+}
+
+// sendDigest menggabungkan item pending satu user menjadi satu email, sekali
+// sehari setelah jam digest di zona waktu user
+func (s *NotificationServiceImpl) sendDigest(ctx context.Context, userId uuid.UUID, now time.Time) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	preference := s.findPreference(ctx, tx, userId)
+	location := helper.LoadLocation(profileTimezone(ctx, tx, s.ProfileRepository, userId))
+	// User yang berhenti berlangganan atau sudah dihapus tidak dikirimi digest
+	user, userErr := s.UserRepository.FindById(ctx, tx, userId)
+	skip := preference.EmailMode == domain.EmailModeOff || userErr != nil
+	if !skip {
+		if now.In(location).Hour() < preference.DigestHour {
+			return
+		}
+		if preference.LastDigestAt != nil && helper.StartOfDay(*preference.LastDigestAt, location).Equal(helper.StartOfDay(now, location)) {
+			return
+		}
+	}
+
+	items := s.EmailDigestItemRepository.FindPendingByUserId(ctx, tx, userId)
+	if len(items) == 0 {
+		return
+	}
+	itemIds := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		itemIds[i] = item.Id
+	}
+	s.EmailDigestItemRepository.MarkDigested(ctx, tx, itemIds, now)
+	if skip {
+		return
+	}
+
+	data := mail.DigestData{
+		RecipientName:  user.FullName,
+		Date:           now.In(location).Format("2006-01-02"),
+		UnsubscribeUrl: s.unsubscribeUrl(user.Id),
+	}
+	for _, item := range items {
+		data.Items = append(data.Items, mail.DigestItemData{
+			Kind:        item.Kind,
+			TaskTitle:   item.TaskTitle,
+			ProjectName: item.ProjectName,
+			ActorName:   item.ActorName,
+			Excerpt:     item.Excerpt,
+			Time:        item.CreatedAt.In(location).Format("2006-01-02 15:04"),
+		})
+	}
+	message, err := s.Templates.Render(mail.TemplateDigest, data)
+	helper.PanicIfError(err)
+	s.enqueue(ctx, tx, user, message)
+
+	preference.LastDigestAt = &now
+	s.NotificationPreferenceRepository.Save(ctx, tx, preference)
+}
+
+func (s *NotificationServiceImpl) NotifyDueSoon(ctx context.Context) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	now := helper.Now()
+	tasks, err := s.TaskRepository.FindDueBetween(ctx, tx, now, now.Add(notificationDueSoonWindow))
+	helper.PanicIfError(err)
+
+	created := 0
+	for _, task := range tasks {
+		location := helper.LoadLocation(profileTimezone(ctx, tx, s.ProfileRepository, *task.AssigneeId))
+		// Satu notifikasi per tenggat; jika tenggat dimundurkan, notifikasi dibuat lagi
+		dedupeKey := domain.NotificationDueSoon + ":" + task.Id.String() + ":" + task.DueDate.UTC().Format(time.RFC3339)
+		_, inserted := s.NotificationRepository.Save(ctx, tx, domain.Notification{
+			UserId:    *task.AssigneeId,
+			Kind:      domain.NotificationDueSoon,
+			ProjectId: &task.ProjectId,
+			TaskId:    &task.Id,
+			Title:     task.Title,
+			Message:   "Jatuh tempo " + task.DueDate.In(location).Format("2006-01-02 15:04"),
+			DedupeKey: &dedupeKey,
+		})
+		if inserted {
+			created++
+		}
+	}
+	if created > 0 {
+		fmt.Printf("🔔 Created %d due-soon notification(s)\n", created)
+	}
+}
+
+func (s *NotificationServiceImpl) Prune(ctx context.Context) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	now := helper.Now()
+	deleted := s.NotificationRepository.DeleteOld(ctx, tx, now.Add(-notificationReadRetention), now.Add(-notificationRetention))
+	if deleted > 0 {
+		fmt.Printf("🗑️ Deleted %d old notification(s)\n", deleted)
+	}
+}
+
+// findPreference mengembalikan preference user, atau default jika belum pernah diatur
+func (s *NotificationServiceImpl) findPreference(ctx context.Context, tx *sql.Tx, userId uuid.UUID) domain.NotificationPreference {
+	preference, err := s.NotificationPreferenceRepository.FindByUserId(ctx, tx, userId)
+	if err != nil {
+		return domain.NotificationPreference{
+			UserId:     userId,
+			EmailMode:  domain.EmailModeInstant,
+			DigestHour: domain.DefaultDigestHour,
+		}
+	}
+	return preference
+}
+
+func (s *NotificationServiceImpl) unsubscribeUrl(userId uuid.UUID) string {
+	return s.PublicBaseUrl + "/public/unsubscribe/" + helper.SignUnsubscribeToken(s.UnsubscribeSecret, userId)
+}
+
+// currentUserId mengambil user yang sedang login, atau panic ForbiddenError
+func currentUserId(ctx context.Context) uuid.UUID {
+	userId, ok := helper.UserIdFromContext(ctx)
+	if !ok {
+		panic(exception.NewForbiddenError("user not found in context"))
+	}
+	return userId
+}
+
+// taskTexts adalah field task yang bisa berisi mention
+func taskTexts(task web.TaskResponse) []string {
+	return []string{task.Title, task.Deliverable, task.Bottleneck, task.Progress}
+}
+
+// findMentions mengembalikan email yang disebut di text (huruf kecil)
+func findMentions(text string) []string {
+	var emails []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		emails = append(emails, strings.ToLower(match[1]))
+	}
+	return emails
+}
+
+// excerpt memotong text menjadi satu baris pendek untuk email
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= notificationExcerptLength {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:notificationExcerptLength])) + "…"
+}
+
+// taskNotificationMessage adalah teks singkat notifikasi inbox
+func taskNotificationMessage(notification pendingNotification, actorName string, task web.TaskResponse, previous *web.TaskResponse) string {
+	switch notification.Kind {
+	case domain.NotificationAssigned:
+		if actorName != "" {
+			return actorName + " menugaskan task ini kepada Anda"
+		}
+		return "Anda ditugaskan ke task ini"
+	case domain.NotificationMentioned:
+		if actorName != "" {
+			return actorName + " menyebut Anda: " + notification.Excerpt
+		}
+		return "Anda disebut: " + notification.Excerpt
+	case domain.NotificationStatusChanged:
+		change := "status berubah menjadi " + task.Status
+		if previous != nil {
+			change = "status berubah dari " + previous.Status + " menjadi " + task.Status
+		}
+		if actorName != "" {
+			return actorName + ": " + change
+		}
+		return strings.ToUpper(change[:1]) + change[1:]
+	case domain.NotificationTaskUpdated:
+		change := "task diperbarui"
+		if previous != nil {
+			change += ": " + strings.Join(changedTaskFields(*previous, task), ", ")
+		}
+		if actorName != "" {
+			return actorName + ": " + change
+		}
+		return strings.ToUpper(change[:1]) + change[1:]
+	}
+	return ""
+}
+
+// changedTaskFields mengembalikan nama field (sesuai JSON) yang berbeda antara
+// dua versi task, di luar status dan timestamp
+func changedTaskFields(previous, task web.TaskResponse) []string {
+	var fields []string
+	add := func(changed bool, name string) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	add(previous.Title != task.Title, "title")
+	add(previous.Priority != task.Priority, "priority")
+	add(previous.Effort != task.Effort, "effort")
+	add(previous.DifficultyLevel != task.DifficultyLevel, "difficulty_level")
+	add(previous.Deliverable != task.Deliverable, "deliverable")
+	add(previous.Bottleneck != task.Bottleneck, "bottleneck")
+	add(previous.ContinueTomorrow != task.ContinueTomorrow, "continue_tomorrow")
+	add(previous.Progress != task.Progress, "progress")
+	add(!equalUUIDPointer(previous.AssigneeId, task.AssigneeId), "assignee_id")
+	add(strings.Join(previous.Labels, "\x00") != strings.Join(task.Labels, "\x00"), "labels")
+	add(!equalTimePointer(previous.DueDate, task.DueDate), "due_date")
+	return fields
+}
+
+func equalUUIDPointer(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePointer(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func toNotificationResponse(notification domain.Notification) web.NotificationResponse {
+	return web.NotificationResponse{
+		Id:        notification.Id,
+		Kind:      notification.Kind,
+		ProjectId: notification.ProjectId,
+		TaskId:    notification.TaskId,
+		ActorId:   notification.ActorId,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Read:      notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func toNotificationPreferenceResponse(preference domain.NotificationPreference, timezone string) web.NotificationPreferenceResponse {
+	response := web.NotificationPreferenceResponse{
+		EmailMode:      preference.EmailMode,
+		DigestHour:     preference.DigestHour,
+		DigestTimezone: timezone,
+	}
+	if !preference.UpdatedAt.IsZero() {
+		response.UpdatedAt = &preference.UpdatedAt
+	}
+	return response
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"task-management/event"
+)
+
+// OutboxService menulis domain event ke tabel outbox dan meneruskannya ke sink.
+type OutboxService interface {
+	// Subscribe mendaftarkan penulis outbox untuk event task, project dan user.
+	Subscribe(bus *event.Bus)
+
+	// Relay mengirim event outbox yang belum terkirim ke semua sink (dijalankan job terjadwal).
+	Relay(ctx context.Context)
+
+	// Cleanup menghapus event yang sudah lama terkirim.
+	Cleanup(ctx context.Context)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+const (
+	// outboxBatchSize adalah jumlah event yang dikunci per transaksi relay
+	outboxBatchSize = 100
+	// outboxMaxBatches membatasi jumlah transaksi per putaran job
+	outboxMaxBatches = 20
+	// Event yang gagal dicoba lagi terus (urutan per aggregate harus terjaga)
+	outboxBaseBackoff = 5 * time.Second
+	outboxMaxBackoff  = 10 * time.Minute
+	// outboxRetention adalah lama event terkirim disimpan sebelum dihapus
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+type OutboxServiceImpl struct {
+	OutboxRepository  repository.OutboxRepository
+	ProjectRepository repository.ProjectRepository
+	DB                *sql.DB
+	Sinks             []event.Sink
+}
+
+func NewOutboxService(
+	outboxRepository repository.OutboxRepository,
+	projectRepository repository.ProjectRepository,
+	db *sql.DB,
+	sinks []event.Sink,
+) OutboxService {
+	return &OutboxServiceImpl{
+		OutboxRepository:  outboxRepository,
+		ProjectRepository: projectRepository,
+		DB:                db,
+		Sinks:             sinks,
+	}
+}
+
+func (s *OutboxServiceImpl) Subscribe(bus *event.Bus) {
+	for _, eventName := range domain.ProjectEventNames {
+		bus.Subscribe(eventName, s.record)
+	}
+	bus.Subscribe(domain.EventUserRegistered, s.record)
+	bus.Subscribe(domain.EventUserUpdated, s.record)
+}
+
+// record menulis event ke outbox di transaksi yang sama dengan perubahan datanya,
+// jadi event hanya ada jika perubahannya ter-commit (dan sebaliknya).
+func (s *OutboxServiceImpl) record(ctx context.Context, tx *sql.Tx, e domain.Event) {
+	message := web.EventMessage{
+		Id:        uuid.New(),
+		Type:      e.EventName(),
+		CreatedAt: helper.Now(),
+	}
+	if userId, ok := helper.UserIdFromContext(ctx); ok {
+		message.ActorId = &userId
+	}
+
+	switch e := e.(type) {
+	case domain.TaskEvent:
+		task := e.Current()
+		message.AggregateType, message.AggregateId = domain.AggregateTask, task.Id
+		message.ProjectId = &task.ProjectId
+		if project, err := s.ProjectRepository.FindById(ctx, tx, task.ProjectId); err == nil {
+			message.WorkspaceId = &project.WorkspaceId
+		}
+
+		data := web.TaskEventData{Task: helper.ToTaskResponse(task)}
+		if e.Before != nil && e.After != nil {
+			previous := helper.ToTaskResponse(*e.Before)
+			data.Previous = &previous
+		}
+		message.Data = data
+	case domain.ProjectEvent:
+		project := e.Current()
+		message.AggregateType, message.AggregateId = domain.AggregateProject, project.Id
+		message.ProjectId = &project.Id
+		message.WorkspaceId = &project.WorkspaceId
+
+		data := web.ProjectEventData{Project: toProjectResponse(project)}
+		if e.Before != nil && e.After != nil {
+			previous := toProjectResponse(*e.Before)
+			data.Previous = &previous
+		}
+		message.Data = data
+	case domain.UserRegistered:
+		message.AggregateType, message.AggregateId = domain.AggregateUser, e.User.Id
+		message.WorkspaceId = &e.User.WorkspaceId
+		message.Data = toUserEventData(e.User)
+	case domain.UserUpdated:
+		message.AggregateType, message.AggregateId = domain.AggregateUser, e.User.Id
+		message.WorkspaceId = &e.User.WorkspaceId
+		message.Data = toUserEventData(e.User)
+	default:
+		return
+	}
+
+	payload, err := json.Marshal(message)
+	helper.PanicIfError(err)
+
+	s.OutboxRepository.Save(ctx, tx, domain.OutboxEvent{
+		Id:            message.Id,
+		AggregateType: message.AggregateType,
+		AggregateId:   message.AggregateId,
+		EventType:     message.Type,
+		WorkspaceId:   message.WorkspaceId,
+		Payload:       string(payload),
+		CreatedAt:     message.CreatedAt,
+	})
+}
+
+func (s *OutboxServiceImpl) Relay(ctx context.Context) {
+	for i := 0; i < outboxMaxBatches; i++ {
+		if s.relayBatch(ctx) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// relayBatch memproses satu batch di satu transaksi. Kunci baris ditahan sampai
+// commit, jadi relay lain (instance lain) melewati event yang sama. Jika proses
+// mati sebelum commit, event dikirim ulang: at-least-once.
+func (s *OutboxServiceImpl) relayBatch(ctx context.Context) int {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	now := helper.Now()
+	events := s.OutboxRepository.ClaimNext(ctx, tx, now, outboxBatchSize)
+	for _, outboxEvent := range events {
+		// Perubahan database dari sink dibatalkan jika ada sink yang gagal,
+		// supaya percobaan berikutnya tidak membuat duplikat di database
+		err := helper.WithSavepoint(ctx, tx, "outbox_event", func() error {
+			return s.dispatch(ctx, tx, outboxEvent)
+		})
+		if err == nil {
+			s.OutboxRepository.MarkPublished(ctx, tx, outboxEvent.Id, now)
+			continue
+		}
+
+		outboxEvent.Attempts++
+		outboxEvent.LastError = err.Error()
+		outboxEvent.NextAttemptAt = now.Add(helper.Backoff(outboxBaseBackoff, outboxMaxBackoff, outboxEvent.Attempts))
+		s.OutboxRepository.MarkFailed(ctx, tx, outboxEvent)
+		fmt.Printf("⚠️ Outbox event %s (%s) failed, attempt %d: %s\n", outboxEvent.Id, outboxEvent.EventType, outboxEvent.Attempts, err)
+	}
+	return len(events)
+}
+
+// dispatch mengirim event ke semua sink; panic dari sink dianggap error
+func (s *OutboxServiceImpl) dispatch(ctx context.Context, tx *sql.Tx, outboxEvent domain.OutboxEvent) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	var failures []string
+	for _, sink := range s.Sinks {
+		if sinkErr := sink.Handle(ctx, tx, outboxEvent); sinkErr != nil {
+			failures = append(failures, sink.Name()+": "+sinkErr.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (s *OutboxServiceImpl) Cleanup(ctx context.Context) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	deleted := s.OutboxRepository.DeletePublished(ctx, tx, helper.Now().Add(-outboxRetention))
+	if deleted > 0 {
+		fmt.Printf("🗑️ Deleted %d published outbox event(s)\n", deleted)
+	}
+}
+
+func toUserEventData(user domain.User) web.UserEventData {
+	return web.UserEventData{
+		Id:          user.Id,
+		Email:       user.Email,
+		Role:        user.Role,
+		FullName:    user.FullName,
+		WorkspaceId: user.WorkspaceId,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+	}
+}
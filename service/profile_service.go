@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
+
 	"github.com/google/uuid"
 	"task-management/model/web"
 )
@@ -13,4 +16,11 @@ type ProfileService interface {
 	FindById(ctx context.Context, profileId uuid.UUID) web.ProfileResponse
 	FindByUserId(ctx context.Context, userId uuid.UUID) web.ProfileResponse
 	FindAll(ctx context.Context) []web.ProfileResponse
+
+	// UploadAvatar memvalidasi gambar dan menyimpan thumbnail avatar user sendiri
+	UploadAvatar(ctx context.Context, userId uuid.UUID, data []byte) web.ProfileResponse
+	// DeleteAvatar menghapus avatar user sendiri
+	DeleteAvatar(ctx context.Context, userId uuid.UUID) web.ProfileResponse
+	// OpenAvatar membuka file thumbnail avatar untuk dikirim ke client
+	OpenAvatar(userId uuid.UUID, size int) (io.ReadSeekCloser, time.Time)
 }
\ No newline at end of file
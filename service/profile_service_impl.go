@@ -3,7 +3,13 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"task-management/exception"
 	"task-management/helper"
 	"task-management/model/domain"
 	"task-management/model/web"
@@ -12,18 +18,25 @@ import (
 
 type ProfileServiceImpl struct {
 	ProfileRepository repository.ProfileRepository
+	AvatarStorage     repository.AvatarStorage
 	DB               *sql.DB
+	Validator         *validator.Validate
 }
 
-func NewProfileService(profileRepository repository.ProfileRepository, db *sql.DB) ProfileService {
+func NewProfileService(profileRepository repository.ProfileRepository, avatarStorage repository.AvatarStorage, db *sql.DB, validator *validator.Validate) ProfileService {
 	return &ProfileServiceImpl{
 		ProfileRepository: profileRepository,
+		AvatarStorage:     avatarStorage,
 		DB:               db,
+		Validator:         validator,
 	}
 }
 
 func (s *ProfileServiceImpl) Create(ctx context.Context, request web.ProfileCreateRequest) web.ProfileResponse {
-	tx, err := s.DB.Begin()
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -33,6 +46,7 @@ func (s *ProfileServiceImpl) Create(ctx context.Context, request web.ProfileCrea
 		Email:    request.Email,
 		Role:     request.Role,
 	}
+	applyProfileDetails(&profile, request.ProfileDetails)
 
 	profile = s.ProfileRepository.Save(ctx, tx, profile)
 
@@ -40,7 +54,10 @@ func (s *ProfileServiceImpl) Create(ctx context.Context, request web.ProfileCrea
 }
 
 func (s *ProfileServiceImpl) Update(ctx context.Context, request web.ProfileUpdateRequest) web.ProfileResponse {
-	tx, err := s.DB.Begin()
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -51,6 +68,7 @@ func (s *ProfileServiceImpl) Update(ctx context.Context, request web.ProfileUpda
 	profile.FullName = request.FullName
 	profile.Email = request.Email
 	profile.Role = request.Role
+	applyProfileDetails(&profile, request.ProfileDetails)
 
 	profile = s.ProfileRepository.Update(ctx, tx, profile)
 
@@ -58,7 +76,7 @@ func (s *ProfileServiceImpl) Update(ctx context.Context, request web.ProfileUpda
 }
 
 func (s *ProfileServiceImpl) Delete(ctx context.Context, profileId uuid.UUID) {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -69,7 +87,7 @@ func (s *ProfileServiceImpl) Delete(ctx context.Context, profileId uuid.UUID) {
 }
 
 func (s *ProfileServiceImpl) FindById(ctx context.Context, profileId uuid.UUID) web.ProfileResponse {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -80,7 +98,7 @@ func (s *ProfileServiceImpl) FindById(ctx context.Context, profileId uuid.UUID)
 }
 
 func (s *ProfileServiceImpl) FindByUserId(ctx context.Context, userId uuid.UUID) web.ProfileResponse {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -91,7 +109,7 @@ func (s *ProfileServiceImpl) FindByUserId(ctx context.Context, userId uuid.UUID)
 }
 
 func (s *ProfileServiceImpl) FindAll(ctx context.Context) []web.ProfileResponse {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -105,12 +123,121 @@ func (s *ProfileServiceImpl) FindAll(ctx context.Context) []web.ProfileResponse
 	return profileResponses
 }
 
+func (s *ProfileServiceImpl) UploadAvatar(ctx context.Context, userId uuid.UUID, data []byte) web.ProfileResponse {
+	authorizeOwnProfile(ctx, userId)
+
+	// Proses gambar di luar transaksi karena cukup berat
+	thumbnails, err := helper.ProcessAvatar(data, helper.AvatarSizes)
+	if err != nil {
+		panic(exception.NewBadRequestError(err.Error()))
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	profile := s.findProfileByUser(ctx, tx, userId)
+	for size, thumbnail := range thumbnails {
+		helper.PanicIfError(s.AvatarStorage.Save(userId, size, thumbnail))
+	}
+
+	now := helper.Now()
+	profile.AvatarUpdatedAt = &now
+	profile = s.ProfileRepository.Update(ctx, tx, profile)
+
+	return toProfileResponse(profile)
+}
+
+func (s *ProfileServiceImpl) DeleteAvatar(ctx context.Context, userId uuid.UUID) web.ProfileResponse {
+	authorizeOwnProfile(ctx, userId)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	profile := s.findProfileByUser(ctx, tx, userId)
+	helper.PanicIfError(s.AvatarStorage.Delete(userId))
+
+	profile.AvatarUpdatedAt = nil
+	profile = s.ProfileRepository.Update(ctx, tx, profile)
+
+	return toProfileResponse(profile)
+}
+
+func (s *ProfileServiceImpl) OpenAvatar(userId uuid.UUID, size int) (io.ReadSeekCloser, time.Time) {
+	if !isAvatarSize(size) {
+		panic(exception.NewNotFoundError(fmt.Sprintf("avatar size %d is not available", size)))
+	}
+	file, modTime, err := s.AvatarStorage.Open(userId, size)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return file, modTime
+}
+
+func (s *ProfileServiceImpl) findProfileByUser(ctx context.Context, tx *sql.Tx, userId uuid.UUID) domain.Profile {
+	profile, err := s.ProfileRepository.FindByUserId(ctx, tx, userId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return profile
+}
+
+// authorizeOwnProfile memastikan avatar hanya diubah oleh pemiliknya sendiri
+func authorizeOwnProfile(ctx context.Context, userId uuid.UUID) {
+	currentUserId, ok := helper.UserIdFromContext(ctx)
+	if !ok || currentUserId != userId {
+		panic(exception.NewForbiddenError("you can only change your own avatar"))
+	}
+}
+
+func isAvatarSize(size int) bool {
+	for _, avatarSize := range helper.AvatarSizes {
+		if avatarSize == size {
+			return true
+		}
+	}
+	return false
+}
+
+func applyProfileDetails(profile *domain.Profile, details web.ProfileDetails) {
+	profile.JobTitle = details.JobTitle
+	if details.Timezone != "" {
+		profile.Timezone = details.Timezone
+	}
+	if details.Locale != "" {
+		profile.Locale = details.Locale
+	}
+	profile.WorkingHoursStart = details.WorkingHoursStart
+	profile.WorkingHoursEnd = details.WorkingHoursEnd
+	profile.Bio = details.Bio
+}
+
 func toProfileResponse(profile domain.Profile) web.ProfileResponse {
-	return web.ProfileResponse{
+	response := web.ProfileResponse{
 		Id:       profile.Id,
 		UserId:   profile.UserId,
 		FullName: profile.FullName,
 		Email:    profile.Email,
 		Role:     profile.Role,
+		ProfileDetails: web.ProfileDetails{
+			JobTitle:          profile.JobTitle,
+			Timezone:          profile.Timezone,
+			Locale:            profile.Locale,
+			WorkingHoursStart: profile.WorkingHoursStart,
+			WorkingHoursEnd:   profile.WorkingHoursEnd,
+			Bio:               profile.Bio,
+		},
+		AvatarUpdatedAt: profile.AvatarUpdatedAt,
+	}
+
+	// Versi di query string membuat URL berubah setiap avatar diganti,
+	// jadi cache browser tidak menampilkan avatar lama
+	if profile.AvatarUpdatedAt != nil {
+		response.Avatars = map[int]string{}
+		for _, size := range helper.AvatarSizes {
+			response.Avatars[size] = fmt.Sprintf("/avatars/%s/%d?v=%d", profile.UserId, size, profile.AvatarUpdatedAt.Unix())
+		}
 	}
+	return response
 }
\ No newline at end of file
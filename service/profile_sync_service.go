@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"task-management/event"
+	"task-management/model/web"
+)
+
+// ProfileSyncService menjaga salinan FullName, Email dan Role di profiles
+// tetap sama dengan users.
+type ProfileSyncService interface {
+	// Subscribe mendaftarkan handler event user ke bus.
+	Subscribe(bus *event.Bus)
+
+	// Reconcile mencari profile yang hilang/berbeda dari user-nya lalu memperbaikinya.
+	// Dengan dryRun, hanya melaporkan tanpa mengubah data.
+	Reconcile(ctx context.Context, dryRun bool) web.ProfileReconcileResult
+}
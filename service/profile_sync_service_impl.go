@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"task-management/event"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+type ProfileSyncServiceImpl struct {
+	UserRepository    repository.UserRepository
+	ProfileRepository repository.ProfileRepository
+	DB                *sql.DB
+}
+
+func NewProfileSyncService(userRepository repository.UserRepository, profileRepository repository.ProfileRepository, db *sql.DB) ProfileSyncService {
+	return &ProfileSyncServiceImpl{
+		UserRepository:    userRepository,
+		ProfileRepository: profileRepository,
+		DB:                db,
+	}
+}
+
+func (s *ProfileSyncServiceImpl) Subscribe(bus *event.Bus) {
+	bus.Subscribe(domain.EventUserRegistered, func(ctx context.Context, tx *sql.Tx, e domain.Event) {
+		s.syncProfile(ctx, tx, e.(domain.UserRegistered).User)
+	})
+	bus.Subscribe(domain.EventUserUpdated, func(ctx context.Context, tx *sql.Tx, e domain.Event) {
+		s.syncProfile(ctx, tx, e.(domain.UserUpdated).User)
+	})
+}
+
+func (s *ProfileSyncServiceImpl) Reconcile(ctx context.Context, dryRun bool) web.ProfileReconcileResult {
+	tx, err := helper.BeginTx(helper.ContextWithSystem(ctx), s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	profilesByUser := map[string]domain.Profile{}
+	for _, profile := range s.ProfileRepository.FindAll(ctx, tx) {
+		profilesByUser[profile.UserId.String()] = profile
+	}
+
+	result := web.ProfileReconcileResult{DryRun: dryRun}
+	for _, user := range s.UserRepository.FindAll(ctx, tx) {
+		result.Checked++
+		profile, exists := profilesByUser[user.Id.String()]
+		switch {
+		case !exists:
+			result.Created = append(result.Created, user.Id)
+		case profileDrifted(profile, user):
+			result.Updated = append(result.Updated, user.Id)
+		default:
+			continue
+		}
+		if !dryRun {
+			s.syncProfile(ctx, tx, user)
+		}
+	}
+	return result
+}
+
+// syncProfile menyalin data user ke profile-nya, membuat profile jika belum ada
+func (s *ProfileSyncServiceImpl) syncProfile(ctx context.Context, tx *sql.Tx, user domain.User) {
+	profile, err := s.ProfileRepository.FindByUserId(ctx, tx, user.Id)
+	if err != nil {
+		s.ProfileRepository.Save(ctx, tx, domain.Profile{
+			UserId:   user.Id,
+			FullName: user.FullName,
+			Email:    user.Email,
+			Role:     user.Role,
+		})
+		return
+	}
+	if !profileDrifted(profile, user) {
+		return
+	}
+
+	profile.FullName = user.FullName
+	profile.Email = user.Email
+	profile.Role = user.Role
+	s.ProfileRepository.Update(ctx, tx, profile)
+}
+
+// profileDrifted membandingkan dengan normalisasi yang sama seperti ProfileRepository
+func profileDrifted(profile domain.Profile, user domain.User) bool {
+	return profile.FullName != strings.TrimSpace(user.FullName) ||
+		profile.Email != strings.ToLower(strings.TrimSpace(user.Email)) ||
+		profile.Role != user.Role
+}
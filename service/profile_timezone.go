@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+	"task-management/repository"
+)
+
+// profileTimezone mengambil zona waktu dari profile user, atau
+// domain.DefaultProfileTimezone jika user belum punya profile. Semua tempat
+// yang menghitung "hari ini" untuk user memakai fallback yang sama. Error
+// database selain profile tidak ditemukan sudah di-panic oleh repository.
+func profileTimezone(ctx context.Context, tx *sql.Tx, profileRepository repository.ProfileRepository, userId uuid.UUID) string {
+	profile, err := profileRepository.FindByUserId(ctx, tx, userId)
+	if err != nil || profile.Timezone == "" {
+		return domain.DefaultProfileTimezone
+	}
+	return profile.Timezone
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/repository"
+)
+
+// projectRoleRank mengurutkan role project dari yang paling terbatas
+var projectRoleRank = map[string]int{
+	domain.ProjectRoleViewer:     1,
+	domain.ProjectRoleMember:     2,
+	domain.ProjectRoleMaintainer: 3,
+	domain.ProjectRoleOwner:      4,
+}
+
+// projectRole mengembalikan role user di project ("" jika bukan anggota).
+// Pembuat project (projects.user_id) selalu dianggap owner.
+func projectRole(ctx context.Context, tx *sql.Tx, projectMemberRepository repository.ProjectMemberRepository, project domain.Project, userId uuid.UUID) string {
+	if project.UserId == userId {
+		return domain.ProjectRoleOwner
+	}
+	member, err := projectMemberRepository.FindByProjectAndUser(ctx, tx, project.Id, userId)
+	if err != nil {
+		return ""
+	}
+	return member.Role
+}
+
+// hasProjectRole bernilai true jika role minimal setara minRole
+func hasProjectRole(role string, minRole string) bool {
+	return projectRoleRank[role] >= projectRoleRank[minRole]
+}
+
+// canAccessProject memeriksa apakah user yang sedang login punya minimal minRole di project
+func canAccessProject(ctx context.Context, tx *sql.Tx, projectMemberRepository repository.ProjectMemberRepository, project domain.Project, minRole string) bool {
+	userId, ok := helper.UserIdFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return hasProjectRole(projectRole(ctx, tx, projectMemberRepository, project, userId), minRole)
+}
+
+// authorizeProject panic dengan ForbiddenError jika user yang sedang login
+// tidak punya minimal minRole di project.
+func authorizeProject(ctx context.Context, tx *sql.Tx, projectMemberRepository repository.ProjectMemberRepository, project domain.Project, minRole string) {
+	if !canAccessProject(ctx, tx, projectMemberRepository, project, minRole) {
+		panic(exception.NewForbiddenError("requires " + minRole + " role in project " + project.Id.String()))
+	}
+}
+
+// addProjectOwner mendaftarkan pembuat project sebagai anggota dengan role owner
+func addProjectOwner(ctx context.Context, tx *sql.Tx, projectMemberRepository repository.ProjectMemberRepository, project domain.Project) {
+	projectMemberRepository.Save(ctx, tx, domain.ProjectMember{
+		ProjectId: project.Id,
+		UserId:    project.UserId,
+		Role:      domain.ProjectRoleOwner,
+	})
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+// burndownTimeline adalah rangkaian kondisi satu task di dalam project.
+// Sebelum events[0] (atau sebelum existsFrom) task belum ada di project.
+type burndownTimeline struct {
+	existsFrom    time.Time
+	initialStatus string
+	initialEffort int
+	events        []domain.TaskStatusHistory
+}
+
+// buildBurndownTimelines menggabungkan task yang ada sekarang dengan riwayat
+// status project. Task lama yang belum punya riwayat dianggap berstatus
+// seperti sekarang sejak dibuat; task completed dianggap selesai saat
+// terakhir di-update.
+func buildBurndownTimelines(tasks []domain.Task, histories []domain.TaskStatusHistory) []burndownTimeline {
+	eventsByTask := map[uuid.UUID][]domain.TaskStatusHistory{}
+	var taskOrder []uuid.UUID
+	for _, history := range histories {
+		if _, ok := eventsByTask[history.TaskId]; !ok {
+			taskOrder = append(taskOrder, history.TaskId)
+		}
+		eventsByTask[history.TaskId] = append(eventsByTask[history.TaskId], history)
+	}
+
+	currentTasks := map[uuid.UUID]domain.Task{}
+	for _, task := range tasks {
+		currentTasks[task.Id] = task
+	}
+
+	var timelines []burndownTimeline
+	for _, task := range tasks {
+		if _, ok := eventsByTask[task.Id]; ok {
+			continue
+		}
+		timeline := burndownTimeline{existsFrom: task.CreatedAt, initialStatus: task.Status, initialEffort: task.Effort}
+		if task.Status == "completed" && task.UpdatedAt.After(task.CreatedAt) {
+			timeline.initialStatus = "todo"
+			timeline.events = []domain.TaskStatusHistory{{
+				FromStatus: "todo",
+				ToStatus:   task.Status,
+				Effort:     task.Effort,
+				ChangedAt:  task.UpdatedAt,
+			}}
+		}
+		timelines = append(timelines, timeline)
+	}
+
+	for _, taskId := range taskOrder {
+		events := eventsByTask[taskId]
+		first := events[0]
+		timeline := burndownTimeline{
+			existsFrom:    first.ChangedAt,
+			initialStatus: first.FromStatus,
+			initialEffort: first.Effort,
+			events:        events,
+		}
+		// Riwayat yang tidak diawali event masuk berarti task sudah ada
+		// sebelum riwayat mulai dicatat
+		if first.FromStatus != "" {
+			if task, ok := currentTasks[taskId]; ok && task.CreatedAt.Before(first.ChangedAt) {
+				timeline.existsFrom = task.CreatedAt
+			}
+		}
+		timelines = append(timelines, timeline)
+	}
+
+	return timelines
+}
+
+// stateAt mengembalikan status dan effort task pada waktu at.
+// exists bernilai false jika task belum masuk atau sudah keluar dari project.
+func (timeline burndownTimeline) stateAt(at time.Time) (status string, effort int, exists bool) {
+	if at.Before(timeline.existsFrom) {
+		return "", 0, false
+	}
+	status, effort = timeline.initialStatus, timeline.initialEffort
+	for _, event := range timeline.events {
+		if event.ChangedAt.After(at) {
+			break
+		}
+		status, effort = event.ToStatus, event.Effort
+	}
+	if status == "" || status == domain.TaskStatusDeleted {
+		return status, effort, false
+	}
+	return status, effort, true
+}
+
+// burndownWeight menghitung bobot task sesuai unit. Untuk unit effort,
+// effort kosong dihitung 1 seperti pada perhitungan progress project.
+func burndownWeight(unit string, effort int) float64 {
+	if unit == "count" || effort <= 0 {
+		return 1
+	}
+	return float64(effort)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"task-management/model/web"
+)
+
+// ProjectBurndownService menyusun data chart burndown/burnup project dari
+// riwayat status task.
+type ProjectBurndownService interface {
+	// Burndown mengembalikan deret harian sisa dan selesai (default: sejak project dimulai sampai hari ini).
+	Burndown(ctx context.Context, request web.ProjectBurndownRequest) web.ProjectBurndownResponse
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+// burndownMaxDays membatasi panjang deret agar response tetap kecil
+const burndownMaxDays = 366
+
+type ProjectBurndownServiceImpl struct {
+	HistoryRepository       repository.TaskStatusHistoryRepository
+	ProjectRepository       repository.ProjectRepository
+	TaskRepository          repository.TaskRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	DB                      *sql.DB
+	Validator               *validator.Validate
+}
+
+func NewProjectBurndownService(
+	historyRepository repository.TaskStatusHistoryRepository,
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	db *sql.DB,
+	validator *validator.Validate,
+) ProjectBurndownService {
+	return &ProjectBurndownServiceImpl{
+		HistoryRepository:       historyRepository,
+		ProjectRepository:       projectRepository,
+		TaskRepository:          taskRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		DB:                      db,
+		Validator:               validator,
+	}
+}
+
+func (s *ProjectBurndownServiceImpl) Burndown(ctx context.Context, request web.ProjectBurndownRequest) web.ProjectBurndownResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, request.ProjectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
+	unit := request.Unit
+	if unit == "" {
+		unit = "effort"
+	}
+
+	// Batas hari mengikuti zona waktu user yang meminta laporan
+	location := helper.LocationFromContext(ctx)
+	to := helper.StartOfDay(helper.Now(), location)
+	if request.To != nil {
+		to = helper.StartOfDay(*request.To, location)
+	}
+	from := helper.StartOfDay(projectBaseDate(project), location)
+	if request.From != nil {
+		from = helper.StartOfDay(*request.From, location)
+	}
+	if to.Before(from) {
+		panic(exception.NewBadRequestError("'to' must not be before 'from'"))
+	}
+	if earliest := to.AddDate(0, 0, -(burndownMaxDays - 1)); from.Before(earliest) {
+		from = earliest
+	}
+
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, project.Id)
+	helper.PanicIfError(err)
+	histories := s.HistoryRepository.FindByProjectId(ctx, tx, project.Id)
+	timelines := buildBurndownTimelines(tasks, histories)
+
+	response := web.ProjectBurndownResponse{
+		ProjectId: project.Id,
+		Unit:      unit,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Points:    []web.ProjectBurndownPoint{},
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		// Kondisi dihitung pada akhir hari
+		endOfDay := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+		point := web.ProjectBurndownPoint{Date: day.Format("2006-01-02")}
+		for _, timeline := range timelines {
+			status, effort, exists := timeline.stateAt(endOfDay)
+			if !exists {
+				continue
+			}
+			weight := burndownWeight(unit, effort)
+			point.Scope += weight
+			if status == "completed" {
+				point.Completed += weight
+			}
+		}
+		point.Remaining = point.Scope - point.Completed
+
+		// Perubahan scope ditandai dibanding hari sebelumnya
+		if n := len(response.Points); n > 0 && point.Scope != response.Points[n-1].Scope {
+			point.ScopeChanged = true
+			point.ScopeChange = point.Scope - response.Points[n-1].Scope
+		}
+		response.Points = append(response.Points, point)
+	}
+
+	return response
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+func TestBurndownTimelineStateAt(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	taskId := uuid.New()
+
+	tests := []struct {
+		name       string
+		tasks      []domain.Task
+		histories  []domain.TaskStatusHistory
+		at         time.Time
+		wantStatus string
+		wantEffort int
+		wantExists bool
+	}{
+		{
+			name:      "before task enters project",
+			histories: []domain.TaskStatusHistory{{TaskId: taskId, ToStatus: "todo", Effort: 3, ChangedAt: day(2)}},
+			at:        day(1),
+		},
+		{
+			name: "status and effort follow history",
+			histories: []domain.TaskStatusHistory{
+				{TaskId: taskId, ToStatus: "todo", Effort: 3, ChangedAt: day(2)},
+				{TaskId: taskId, FromStatus: "todo", ToStatus: "completed", Effort: 5, ChangedAt: day(4)},
+			},
+			at:         day(3),
+			wantStatus: "todo",
+			wantEffort: 3,
+			wantExists: true,
+		},
+		{
+			name: "deleted task no longer exists",
+			histories: []domain.TaskStatusHistory{
+				{TaskId: taskId, ToStatus: "todo", Effort: 3, ChangedAt: day(2)},
+				{TaskId: taskId, FromStatus: "todo", ToStatus: domain.TaskStatusDeleted, Effort: 3, ChangedAt: day(4)},
+			},
+			at:         day(5),
+			wantStatus: domain.TaskStatusDeleted,
+			wantEffort: 3,
+		},
+		{
+			name:  "history starting mid-life uses task creation time",
+			tasks: []domain.Task{{Id: taskId, Status: "completed", Effort: 2, CreatedAt: day(1)}},
+			histories: []domain.TaskStatusHistory{
+				{TaskId: taskId, FromStatus: "todo", ToStatus: "completed", Effort: 2, ChangedAt: day(4)},
+			},
+			at:         day(2),
+			wantStatus: "todo",
+			wantEffort: 2,
+			wantExists: true,
+		},
+		{
+			name:       "legacy completed task without history completes at last update",
+			tasks:      []domain.Task{{Id: taskId, Status: "completed", Effort: 2, CreatedAt: day(1), UpdatedAt: day(3)}},
+			at:         day(2),
+			wantStatus: "todo",
+			wantEffort: 2,
+			wantExists: true,
+		},
+		{
+			name:       "legacy completed task after last update",
+			tasks:      []domain.Task{{Id: taskId, Status: "completed", Effort: 2, CreatedAt: day(1), UpdatedAt: day(3)}},
+			at:         day(4),
+			wantStatus: "completed",
+			wantEffort: 2,
+			wantExists: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timelines := buildBurndownTimelines(test.tasks, test.histories)
+			if len(timelines) != 1 {
+				t.Fatalf("got %d timelines, want 1", len(timelines))
+			}
+			status, effort, exists := timelines[0].stateAt(test.at)
+			if status != test.wantStatus || effort != test.wantEffort || exists != test.wantExists {
+				t.Fatalf("got (%q, %d, %v), want (%q, %d, %v)", status, effort, exists, test.wantStatus, test.wantEffort, test.wantExists)
+			}
+		})
+	}
+}
+
+func TestBurndownWeight(t *testing.T) {
+	tests := []struct {
+		unit   string
+		effort int
+		want   float64
+	}{
+		{"effort", 5, 5},
+		{"effort", 0, 1},
+		{"count", 5, 1},
+	}
+	for _, test := range tests {
+		if got := burndownWeight(test.unit, test.effort); got != test.want {
+			t.Errorf("burndownWeight(%q, %d) = %v, want %v", test.unit, test.effort, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/event"
+)
+
+// ProjectEventService memberi akses stream perubahan task sebuah project.
+type ProjectEventService interface {
+	// Subscribe memeriksa akses user lalu mendaftarkannya ke stream project.
+	// Pemanggil wajib memanggil Unsubscribe setelah selesai.
+	Subscribe(ctx context.Context, projectId uuid.UUID, lastEventId string) *event.Subscription
+
+	Unsubscribe(subscription *event.Subscription)
+}
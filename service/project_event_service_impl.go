@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/repository"
+)
+
+type ProjectEventServiceImpl struct {
+	ProjectRepository       repository.ProjectRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	Broker                  *event.Broker
+	DB                      *sql.DB
+}
+
+func NewProjectEventService(
+	projectRepository repository.ProjectRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	broker *event.Broker,
+	db *sql.DB,
+) ProjectEventService {
+	return &ProjectEventServiceImpl{
+		ProjectRepository:       projectRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		Broker:                  broker,
+		DB:                      db,
+	}
+}
+
+func (s *ProjectEventServiceImpl) Subscribe(ctx context.Context, projectId uuid.UUID, lastEventId string) *event.Subscription {
+	// Transaksi hanya untuk cek akses; tidak ditahan selama stream berjalan
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
+	return s.Broker.Subscribe(project.Id, lastEventId)
+}
+
+func (s *ProjectEventServiceImpl) Unsubscribe(subscription *event.Subscription) {
+	s.Broker.Unsubscribe(subscription)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+)
+
+// Batas skor kesehatan project: >= healthGreenScore hijau, >= healthAmberScore kuning
+const (
+	healthGreenScore = 75
+	healthAmberScore = 50
+)
+
+// healthPenalty menghitung pengurangan skor: perUnit untuk setiap kejadian, maksimal max
+func healthPenalty(count int, perUnit float64, max float64) float64 {
+	return math.Min(float64(count)*perUnit, max)
+}
+
+// inProgressSince mengembalikan waktu terakhir setiap task masuk status in-progress
+func inProgressSince(histories []domain.TaskStatusHistory) map[uuid.UUID]time.Time {
+	since := map[uuid.UUID]time.Time{}
+	for _, history := range histories {
+		if history.ToStatus == "in-progress" && history.FromStatus != "in-progress" {
+			since[history.TaskId] = history.ChangedAt
+		}
+	}
+	return since
+}
+
+// evaluateProjectHealth memberi skor 0-100 dari kondisi task project beserta alasannya.
+// Task in-progress tanpa riwayat dianggap mulai dikerjakan saat terakhir di-update.
+// Task baru overdue setelah hari due date-nya lewat, menurut zona waktu now.
+func evaluateProjectHealth(projectId uuid.UUID, tasks []domain.Task, histories []domain.TaskStatusHistory, stuckThresholdDays int, now time.Time) web.ProjectHealthResponse {
+	metrics := web.ProjectHealthMetrics{}
+	since := inProgressSince(histories)
+	stuckBefore := now.AddDate(0, 0, -stuckThresholdDays)
+	today := helper.StartOfDay(now, now.Location())
+
+	var openEffort, highPriorityEffort float64
+	for _, task := range tasks {
+		if task.Status == "completed" {
+			continue
+		}
+		metrics.OpenTasks++
+		effort := burndownWeight("effort", task.Effort)
+		openEffort += effort
+		if task.Priority == "high" {
+			highPriorityEffort += effort
+		}
+		if task.DueDate != nil && helper.StartOfDay(*task.DueDate, now.Location()).Before(today) {
+			metrics.OverdueTasks++
+		}
+		if task.Bottleneck != "" {
+			metrics.OpenBottlenecks++
+		}
+		if task.ContinueTomorrow {
+			metrics.CarriedOverTasks++
+		}
+		if task.Status == "in-progress" {
+			started, ok := since[task.Id]
+			if !ok {
+				started = task.UpdatedAt
+			}
+			if started.Before(stuckBefore) {
+				metrics.StuckTasks++
+			}
+		}
+	}
+	if openEffort > 0 {
+		metrics.HighPriorityRatio = math.Round(highPriorityEffort/openEffort*100) / 100
+	}
+
+	var reasons []web.ProjectHealthReason
+	addReason := func(code string, count int, penalty float64, message string) {
+		if penalty <= 0 {
+			return
+		}
+		reasons = append(reasons, web.ProjectHealthReason{Code: code, Message: message, Count: count, Penalty: penalty})
+	}
+	addReason("overdue_tasks", metrics.OverdueTasks, healthPenalty(metrics.OverdueTasks, 10, 40),
+		fmt.Sprintf("%d open task(s) are past their due date", metrics.OverdueTasks))
+	addReason("open_bottlenecks", metrics.OpenBottlenecks, healthPenalty(metrics.OpenBottlenecks, 8, 24),
+		fmt.Sprintf("%d open task(s) report a bottleneck", metrics.OpenBottlenecks))
+	addReason("stuck_tasks", metrics.StuckTasks, healthPenalty(metrics.StuckTasks, 8, 24),
+		fmt.Sprintf("%d task(s) have been in-progress for more than %d days", metrics.StuckTasks, stuckThresholdDays))
+
+	highPriorityPenalty := 0.0
+	switch {
+	case metrics.HighPriorityRatio > 0.5:
+		highPriorityPenalty = 15
+	case metrics.HighPriorityRatio > 0.3:
+		highPriorityPenalty = 8
+	}
+	addReason("high_priority_ratio", 0, highPriorityPenalty,
+		fmt.Sprintf("%.0f%% of remaining effort is high priority", metrics.HighPriorityRatio*100))
+	addReason("carried_over_tasks", metrics.CarriedOverTasks, healthPenalty(metrics.CarriedOverTasks, 5, 15),
+		fmt.Sprintf("%d task(s) are carried over to tomorrow", metrics.CarriedOverTasks))
+
+	score := 100.0
+	for _, reason := range reasons {
+		score -= reason.Penalty
+	}
+	score = math.Max(score, 0)
+
+	status := "red"
+	switch {
+	case score >= healthGreenScore:
+		status = "green"
+	case score >= healthAmberScore:
+		status = "amber"
+	}
+
+	if reasons == nil {
+		reasons = []web.ProjectHealthReason{}
+	}
+	return web.ProjectHealthResponse{
+		ProjectId:          projectId,
+		Status:             status,
+		Score:              score,
+		StuckThresholdDays: stuckThresholdDays,
+		Metrics:            metrics,
+		Reasons:            reasons,
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+// ProjectHealthService menilai kesehatan project (green/amber/red) dari kondisi task-nya.
+type ProjectHealthService interface {
+	// Health menghitung skor kesehatan project. Task in-progress lebih lama dari
+	// stuckThresholdDays hari dianggap macet.
+	Health(ctx context.Context, projectId uuid.UUID, stuckThresholdDays int) web.ProjectHealthResponse
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+// defaultStuckThresholdDays dipakai jika threshold tidak diberikan
+const defaultStuckThresholdDays = 7
+
+type ProjectHealthServiceImpl struct {
+	ProjectRepository       repository.ProjectRepository
+	TaskRepository          repository.TaskRepository
+	HistoryRepository       repository.TaskStatusHistoryRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	DB                      *sql.DB
+}
+
+func NewProjectHealthService(
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	historyRepository repository.TaskStatusHistoryRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	db *sql.DB,
+) ProjectHealthService {
+	return &ProjectHealthServiceImpl{
+		ProjectRepository:       projectRepository,
+		TaskRepository:          taskRepository,
+		HistoryRepository:       historyRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		DB:                      db,
+	}
+}
+
+func (s *ProjectHealthServiceImpl) Health(ctx context.Context, projectId uuid.UUID, stuckThresholdDays int) web.ProjectHealthResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
+	if stuckThresholdDays <= 0 {
+		stuckThresholdDays = defaultStuckThresholdDays
+	}
+
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, project.Id)
+	helper.PanicIfError(err)
+	histories := s.HistoryRepository.FindByProjectId(ctx, tx, project.Id)
+
+	return evaluateProjectHealth(project.Id, tasks, histories, stuckThresholdDays, helper.Now().In(helper.LocationFromContext(ctx)))
+}
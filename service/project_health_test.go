@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/domain"
+)
+
+func TestEvaluateProjectHealth(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	laterToday := now.Add(10 * time.Hour)
+
+	tests := []struct {
+		name       string
+		tasks      []domain.Task
+		wantStatus string
+		wantScore  float64
+		wantCodes  []string
+	}{
+		{
+			name:       "no tasks",
+			wantStatus: "green",
+			wantScore:  100,
+		},
+		{
+			name: "completed tasks are ignored",
+			tasks: []domain.Task{
+				{Id: uuid.New(), Status: "completed", DueDate: &yesterday, Bottleneck: "blocked", Priority: "high"},
+			},
+			wantStatus: "green",
+			wantScore:  100,
+		},
+		{
+			name: "due later today is not overdue",
+			tasks: []domain.Task{
+				{Id: uuid.New(), Status: "todo", DueDate: &laterToday, Effort: 1},
+			},
+			wantStatus: "green",
+			wantScore:  100,
+		},
+		{
+			name: "overdue and bottleneck",
+			tasks: []domain.Task{
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday, Effort: 1},
+				{Id: uuid.New(), Status: "todo", Bottleneck: "waiting for review", Effort: 1},
+			},
+			wantStatus: "green",
+			wantScore:  82,
+			wantCodes:  []string{"overdue_tasks", "open_bottlenecks"},
+		},
+		{
+			name: "overdue penalty is capped",
+			tasks: []domain.Task{
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday},
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday},
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday},
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday},
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday},
+				{Id: uuid.New(), Status: "todo", DueDate: &yesterday},
+			},
+			wantStatus: "amber",
+			wantScore:  60,
+			wantCodes:  []string{"overdue_tasks"},
+		},
+		{
+			name: "mostly high priority and stuck",
+			tasks: []domain.Task{
+				{Id: uuid.New(), Status: "in-progress", Priority: "high", Effort: 3, UpdatedAt: now.AddDate(0, 0, -10)},
+				{Id: uuid.New(), Status: "todo", Priority: "low", Effort: 1, ContinueTomorrow: true},
+			},
+			wantStatus: "amber",
+			wantScore:  100 - 8 - 15 - 5,
+			wantCodes:  []string{"stuck_tasks", "high_priority_ratio", "carried_over_tasks"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			health := evaluateProjectHealth(uuid.New(), test.tasks, nil, 7, now)
+			if health.Status != test.wantStatus || health.Score != test.wantScore {
+				t.Fatalf("got %s %v, want %s %v (reasons %+v)", health.Status, health.Score, test.wantStatus, test.wantScore, health.Reasons)
+			}
+			if len(health.Reasons) != len(test.wantCodes) {
+				t.Fatalf("got reasons %+v, want %v", health.Reasons, test.wantCodes)
+			}
+			for i, code := range test.wantCodes {
+				if health.Reasons[i].Code != code {
+					t.Errorf("reason %d = %s, want %s", i, health.Reasons[i].Code, code)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateProjectHealthStuckUsesHistory(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	taskId := uuid.New()
+	// Baru di-update kemarin, tetapi sudah in-progress sejak 10 hari lalu
+	tasks := []domain.Task{{Id: taskId, Status: "in-progress", UpdatedAt: now.AddDate(0, 0, -1)}}
+	histories := []domain.TaskStatusHistory{
+		{TaskId: taskId, FromStatus: "", ToStatus: "todo", ChangedAt: now.AddDate(0, 0, -12)},
+		{TaskId: taskId, FromStatus: "todo", ToStatus: "in-progress", ChangedAt: now.AddDate(0, 0, -10)},
+		{TaskId: taskId, FromStatus: "in-progress", ToStatus: "in-progress", ChangedAt: now.AddDate(0, 0, -1)},
+	}
+
+	health := evaluateProjectHealth(uuid.New(), tasks, histories, 7, now)
+	if health.Metrics.StuckTasks != 1 {
+		t.Fatalf("stuck tasks = %d, want 1", health.Metrics.StuckTasks)
+	}
+}
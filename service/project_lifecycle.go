@@ -0,0 +1,32 @@
+package service
+
+import (
+	"task-management/exception"
+	"task-management/model/domain"
+)
+
+// projectTransitions berisi perpindahan status project yang diizinkan.
+// Keluar dari archived hanya lewat unarchive.
+var projectTransitions = map[string][]string{
+	domain.ProjectStatusPlanned:   {domain.ProjectStatusActive, domain.ProjectStatusOnHold, domain.ProjectStatusArchived},
+	domain.ProjectStatusActive:    {domain.ProjectStatusOnHold, domain.ProjectStatusCompleted, domain.ProjectStatusArchived},
+	domain.ProjectStatusOnHold:    {domain.ProjectStatusActive, domain.ProjectStatusCompleted, domain.ProjectStatusArchived},
+	domain.ProjectStatusCompleted: {domain.ProjectStatusActive, domain.ProjectStatusArchived},
+}
+
+// canTransitionProject bernilai true jika project boleh pindah dari status from ke to
+func canTransitionProject(from string, to string) bool {
+	for _, allowed := range projectTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureProjectWritable panic dengan ConflictError jika project sudah diarsipkan
+func ensureProjectWritable(project domain.Project) {
+	if project.Status == domain.ProjectStatusArchived {
+		panic(exception.NewConflictError("project " + project.Id.String() + " is archived and read-only"))
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+// ProjectMemberService mengelola anggota project beserta role-nya.
+type ProjectMemberService interface {
+	// FindByProjectId mengambil semua anggota project, termasuk owner utama.
+	FindByProjectId(ctx context.Context, projectId uuid.UUID) []web.ProjectMemberResponse
+
+	// Create menambahkan user sebagai anggota project (minimal maintainer).
+	Create(ctx context.Context, request web.ProjectMemberCreateRequest) web.ProjectMemberResponse
+
+	// Update mengubah role anggota project (minimal maintainer).
+	Update(ctx context.Context, request web.ProjectMemberUpdateRequest) web.ProjectMemberResponse
+
+	// Delete mengeluarkan anggota dari project. Anggota boleh keluar sendiri.
+	Delete(ctx context.Context, projectId uuid.UUID, userId uuid.UUID)
+}
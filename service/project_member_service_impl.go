@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+type ProjectMemberServiceImpl struct {
+	ProjectMemberRepository repository.ProjectMemberRepository
+	ProjectRepository       repository.ProjectRepository
+	UserRepository          repository.UserRepository
+	DB                      *sql.DB
+	Validator               *validator.Validate
+}
+
+func NewProjectMemberService(
+	projectMemberRepository repository.ProjectMemberRepository,
+	projectRepository repository.ProjectRepository,
+	userRepository repository.UserRepository,
+	db *sql.DB,
+	validator *validator.Validate,
+) ProjectMemberService {
+	return &ProjectMemberServiceImpl{
+		ProjectMemberRepository: projectMemberRepository,
+		ProjectRepository:       projectRepository,
+		UserRepository:          userRepository,
+		DB:                      db,
+		Validator:               validator,
+	}
+}
+
+func (s *ProjectMemberServiceImpl) FindByProjectId(ctx context.Context, projectId uuid.UUID) []web.ProjectMemberResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, projectId)
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
+	members := s.ProjectMemberRepository.FindByProjectId(ctx, tx, projectId)
+
+	// Project lama belum punya baris owner di project_members
+	hasOwner := false
+	for _, member := range members {
+		if member.UserId == project.UserId {
+			hasOwner = true
+			break
+		}
+	}
+	if !hasOwner {
+		owner := domain.ProjectMember{
+			ProjectId: project.Id,
+			UserId:    project.UserId,
+			Role:      domain.ProjectRoleOwner,
+			CreatedAt: project.CreatedAt,
+			UpdatedAt: project.UpdatedAt,
+		}
+		if user, err := s.UserRepository.FindById(ctx, tx, project.UserId); err == nil && user.Id != uuid.Nil {
+			owner.FullName = user.FullName
+			owner.Email = user.Email
+		}
+		members = append([]domain.ProjectMember{owner}, members...)
+	}
+
+	var memberResponses []web.ProjectMemberResponse
+	for _, member := range members {
+		memberResponses = append(memberResponses, toProjectMemberResponse(member))
+	}
+	return memberResponses
+}
+
+func (s *ProjectMemberServiceImpl) Create(ctx context.Context, request web.ProjectMemberCreateRequest) web.ProjectMemberResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, request.ProjectId)
+	minRole := domain.ProjectRoleMaintainer
+	if request.Role == domain.ProjectRoleOwner {
+		minRole = domain.ProjectRoleOwner
+	}
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, minRole)
+
+	user, err := s.UserRepository.FindById(ctx, tx, request.UserId)
+	if err != nil || user.Id == uuid.Nil {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+	if user.Id == project.UserId {
+		panic(exception.NewForbiddenError("project owner is already a member"))
+	}
+
+	member := s.ProjectMemberRepository.Save(ctx, tx, domain.ProjectMember{
+		ProjectId: project.Id,
+		UserId:    user.Id,
+		Role:      request.Role,
+	})
+	member.FullName = user.FullName
+	member.Email = user.Email
+
+	return toProjectMemberResponse(member)
+}
+
+func (s *ProjectMemberServiceImpl) Update(ctx context.Context, request web.ProjectMemberUpdateRequest) web.ProjectMemberResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, request.ProjectId)
+	if request.UserId == project.UserId {
+		panic(exception.NewForbiddenError("cannot change the role of the project owner"))
+	}
+
+	member, err := s.ProjectMemberRepository.FindByProjectAndUser(ctx, tx, project.Id, request.UserId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	// Menjadikan atau mencabut owner hanya boleh dilakukan oleh owner
+	minRole := domain.ProjectRoleMaintainer
+	if request.Role == domain.ProjectRoleOwner || member.Role == domain.ProjectRoleOwner {
+		minRole = domain.ProjectRoleOwner
+	}
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, minRole)
+
+	member.Role = request.Role
+	member = s.ProjectMemberRepository.Update(ctx, tx, member)
+
+	return toProjectMemberResponse(member)
+}
+
+func (s *ProjectMemberServiceImpl) Delete(ctx context.Context, projectId uuid.UUID, userId uuid.UUID) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, projectId)
+	if userId == project.UserId {
+		panic(exception.NewForbiddenError("cannot remove the project owner"))
+	}
+
+	member, err := s.ProjectMemberRepository.FindByProjectAndUser(ctx, tx, project.Id, userId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	// Anggota boleh keluar sendiri; selain itu minimal maintainer,
+	// dan owner hanya bisa dikeluarkan oleh owner
+	currentUserId, _ := helper.UserIdFromContext(ctx)
+	if currentUserId != userId {
+		minRole := domain.ProjectRoleMaintainer
+		if member.Role == domain.ProjectRoleOwner {
+			minRole = domain.ProjectRoleOwner
+		}
+		authorizeProject(ctx, tx, s.ProjectMemberRepository, project, minRole)
+	}
+
+	err = s.ProjectMemberRepository.Delete(ctx, tx, project.Id, userId)
+	helper.PanicIfError(err)
+}
+
+func (s *ProjectMemberServiceImpl) findProject(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) domain.Project {
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return project
+}
+
+func toProjectMemberResponse(member domain.ProjectMember) web.ProjectMemberResponse {
+	return web.ProjectMemberResponse{
+		ProjectId: member.ProjectId,
+		UserId:    member.UserId,
+		FullName:  member.FullName,
+		Email:     member.Email,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+		UpdatedAt: member.UpdatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/repository"
+)
+
+// calculateProjectProgress menghitung progress project (0-100) dari task-nya,
+// dibobot berdasarkan Effort. Task completed dihitung 100%, task in-progress
+// memakai nilai Progress-nya jika berupa angka persen (mis. "40" atau "40%").
+func calculateProjectProgress(tasks []domain.Task) float64 {
+	var totalEffort, doneEffort float64
+	for _, task := range tasks {
+		effort := float64(task.Effort)
+		if effort <= 0 {
+			effort = 1
+		}
+		totalEffort += effort
+		doneEffort += effort * taskCompletion(task)
+	}
+	if totalEffort == 0 {
+		return 0
+	}
+	// Dibulatkan 2 desimal sesuai kolom numeric(5,2)
+	return math.Round(doneEffort/totalEffort*10000) / 100
+}
+
+// taskCompletion mengembalikan tingkat penyelesaian task antara 0 dan 1
+func taskCompletion(task domain.Task) float64 {
+	switch task.Status {
+	case "completed":
+		return 1
+	case "in-progress":
+		value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(task.Progress), "%"), 64)
+		if err != nil {
+			return 0
+		}
+		return math.Min(math.Max(value, 0), 100) / 100
+	default:
+		return 0
+	}
+}
+
+// recalculateProjectProgress memperbarui progress project dari task-nya,
+// kecuali project memakai mode manual.
+func recalculateProjectProgress(ctx context.Context, tx *sql.Tx, projectRepository repository.ProjectRepository, taskRepository repository.TaskRepository, projectId uuid.UUID) {
+	project, err := projectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	if project.ProgressMode == domain.ProgressModeManual {
+		return
+	}
+
+	tasks, err := taskRepository.FindByProjectId(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	project.ProgressMode = domain.ProgressModeAuto
+	project.Progress = calculateProjectProgress(tasks)
+	projectRepository.Update(ctx, tx, project)
+}
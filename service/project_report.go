@@ -0,0 +1,247 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/pdf"
+)
+
+const (
+	// projectReportChangeDays adalah rentang riwayat perubahan di laporan
+	projectReportChangeDays = 7
+	projectReportMargin     = 40
+	projectReportFontSize   = 9
+)
+
+// projectReportStatuses adalah urutan kelompok task di laporan: yang sedang dikerjakan dulu
+var projectReportStatuses = []string{"in-progress", "todo", "completed"}
+
+var projectReportTrends = map[string]string{
+	"up":     "Naik",
+	"down":   "Turun",
+	"stable": "Stabil",
+}
+
+// renderProjectReport menyusun laporan status project sebagai PDF. Tanggal
+// ditulis menurut zona waktu now; task baru terlambat setelah hari due date-nya lewat.
+func renderProjectReport(project domain.Project, tasks []domain.Task, histories []domain.TaskStatusHistory, members []domain.ProjectMember, now time.Time) *pdf.Document {
+	location := now.Location()
+	today := helper.StartOfDay(now, location)
+
+	memberNames := map[uuid.UUID]string{}
+	for _, member := range members {
+		memberNames[member.UserId] = member.FullName
+		if member.FullName == "" {
+			memberNames[member.UserId] = member.Email
+		}
+	}
+	assigneeName := func(task domain.Task) string {
+		if task.AssigneeId == nil {
+			return "-"
+		}
+		if name, ok := memberNames[*task.AssigneeId]; ok {
+			return name
+		}
+		return task.AssigneeId.String()[:8]
+	}
+	dueDate := func(task domain.Task) string {
+		if task.DueDate == nil {
+			return "-"
+		}
+		due := task.DueDate.In(location).Format("2006-01-02")
+		if task.Status != "completed" && helper.StartOfDay(*task.DueDate, location).Before(today) {
+			due += " (terlambat)"
+		}
+		return due
+	}
+
+	document := pdf.NewDocument("Laporan status " + project.Name)
+	document.CreatedAt = now
+	flow := pdf.NewFlow(document, projectReportMargin)
+
+	// Header: ringkasan project
+	flow.Paragraph(pdf.HelveticaBold, 18, pdf.Black, project.Name)
+	flow.Paragraph(pdf.Helvetica, 10, pdf.Gray(0.4), "Laporan status project, dibuat "+now.Format("2006-01-02 15:04 MST"))
+	flow.Space(10)
+
+	completedTasks := 0
+	for _, task := range tasks {
+		if task.Status == "completed" {
+			completedTasks++
+		}
+	}
+	trend, ok := projectReportTrends[project.Trend]
+	if !ok {
+		trend = project.Trend
+	}
+	flow.Table(projectReportFontSize, []pdf.Column{
+		{Title: "Status", Width: 0.16},
+		{Title: "Progress", Width: 0.14},
+		{Title: "Confidence", Width: 0.16},
+		{Title: "Trend", Width: 0.14},
+		{Title: "Mulai", Width: 0.13},
+		{Title: "Tenggat", Width: 0.13},
+		{Title: "Task selesai", Width: 0.14},
+	}, [][]string{{
+		project.Status,
+		formatPercent(project.Progress),
+		formatPercent(project.Confidence),
+		trend,
+		formatReportDate(project.StartDate, location),
+		formatReportDate(project.DueDate, location),
+		fmt.Sprintf("%d dari %d", completedTasks, len(tasks)),
+	}})
+	flow.Space(6)
+	flow.ProgressBar(project.Progress/100, 6, pdf.Color{R: 0.2, G: 0.5, B: 0.3})
+	if project.Description != "" {
+		flow.Space(8)
+		flow.Paragraph(pdf.Helvetica, 10, pdf.Black, project.Description)
+	}
+
+	// Task per status, diurutkan dari due date terdekat
+	groups := map[string][]domain.Task{}
+	statuses := append([]string{}, projectReportStatuses...)
+	for _, task := range tasks {
+		if !slices.Contains(statuses, task.Status) {
+			statuses = append(statuses, task.Status)
+		}
+		groups[task.Status] = append(groups[task.Status], task)
+	}
+	for _, status := range statuses {
+		group := groups[status]
+		sort.SliceStable(group, func(i, j int) bool {
+			if (group[i].DueDate == nil) != (group[j].DueDate == nil) {
+				return group[j].DueDate == nil
+			}
+			if group[i].DueDate != nil && !group[i].DueDate.Equal(*group[j].DueDate) {
+				return group[i].DueDate.Before(*group[j].DueDate)
+			}
+			return group[i].Title < group[j].Title
+		})
+
+		effort := 0
+		for _, task := range group {
+			effort += task.Effort
+		}
+		flow.Space(16)
+		flow.Heading(13, fmt.Sprintf("%s (%d task, effort %d)", statusLabel(status), len(group), effort))
+		if len(group) == 0 {
+			flow.Paragraph(pdf.Helvetica, 10, pdf.Gray(0.4), "Tidak ada task.")
+			continue
+		}
+
+		var rows [][]string
+		for _, task := range group {
+			rows = append(rows, []string{task.Title, task.Priority, strconv.Itoa(task.Effort), assigneeName(task), dueDate(task), task.Progress})
+		}
+		flow.Table(projectReportFontSize, []pdf.Column{
+			{Title: "Task", Width: 0.3},
+			{Title: "Prioritas", Width: 0.1},
+			{Title: "Effort", Width: 0.08},
+			{Title: "Assignee", Width: 0.17},
+			{Title: "Tenggat", Width: 0.15},
+			{Title: "Progress", Width: 0.2},
+		}, rows)
+	}
+
+	// Bottleneck dari task yang belum selesai
+	flow.Space(16)
+	flow.Heading(13, "Bottleneck")
+	var bottleneckRows [][]string
+	for _, status := range statuses {
+		for _, task := range groups[status] {
+			if task.Status != "completed" && task.Bottleneck != "" {
+				bottleneckRows = append(bottleneckRows, []string{task.Title, statusLabel(task.Status), assigneeName(task), task.Bottleneck})
+			}
+		}
+	}
+	if len(bottleneckRows) == 0 {
+		flow.Paragraph(pdf.Helvetica, 10, pdf.Gray(0.4), "Tidak ada bottleneck yang dilaporkan.")
+	} else {
+		flow.Table(projectReportFontSize, []pdf.Column{
+			{Title: "Task", Width: 0.3},
+			{Title: "Status", Width: 0.12},
+			{Title: "Assignee", Width: 0.17},
+			{Title: "Bottleneck", Width: 0.41},
+		}, bottleneckRows)
+	}
+
+	// Perubahan status beberapa hari terakhir, terbaru di atas
+	flow.Space(16)
+	flow.Heading(13, fmt.Sprintf("Perubahan %d hari terakhir", projectReportChangeDays))
+	titles := map[uuid.UUID]string{}
+	for _, task := range tasks {
+		titles[task.Id] = task.Title
+	}
+	since := now.AddDate(0, 0, -projectReportChangeDays)
+	var changeRows [][]string
+	for i := len(histories) - 1; i >= 0; i-- {
+		history := histories[i]
+		if history.ChangedAt.Before(since) {
+			break
+		}
+		title, ok := titles[history.TaskId]
+		if !ok {
+			title = "(task sudah dihapus)"
+		}
+		changeRows = append(changeRows, []string{history.ChangedAt.In(location).Format("2006-01-02 15:04"), title, statusChangeLabel(history)})
+	}
+	if len(changeRows) == 0 {
+		flow.Paragraph(pdf.Helvetica, 10, pdf.Gray(0.4), "Tidak ada perubahan status.")
+	} else {
+		flow.Table(projectReportFontSize, []pdf.Column{
+			{Title: "Waktu", Width: 0.2},
+			{Title: "Task", Width: 0.45},
+			{Title: "Perubahan", Width: 0.35},
+		}, changeRows)
+	}
+
+	flow.Footer(8, project.Name+" - laporan status "+now.Format("2006-01-02"))
+	return document
+}
+
+func statusLabel(status string) string {
+	switch status {
+	case "todo":
+		return "To do"
+	case "in-progress":
+		return "In progress"
+	case "completed":
+		return "Completed"
+	case "":
+		return "Tanpa status"
+	}
+	return status
+}
+
+// statusChangeLabel menjelaskan satu riwayat status; FromStatus kosong berarti
+// task baru masuk ke project
+func statusChangeLabel(history domain.TaskStatusHistory) string {
+	switch {
+	case history.ToStatus == domain.TaskStatusDeleted:
+		return "Dihapus dari project"
+	case history.FromStatus == "":
+		return "Ditambahkan sebagai " + statusLabel(history.ToStatus)
+	case history.FromStatus == history.ToStatus:
+		return "Effort diubah menjadi " + strconv.Itoa(history.Effort)
+	}
+	return statusLabel(history.FromStatus) + " -> " + statusLabel(history.ToStatus)
+}
+
+func formatPercent(value float64) string {
+	return fmt.Sprintf("%.0f%%", value)
+}
+
+func formatReportDate(date *time.Time, location *time.Location) string {
+	if date == nil {
+		return "-"
+	}
+	return date.In(location).Format("2006-01-02")
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/pdf"
+)
+
+// ProjectReportService menyusun laporan status project yang bisa dicetak (PDF).
+type ProjectReportService interface {
+	// Report merender ringkasan project, task per status, bottleneck dan
+	// perubahan status 7 hari terakhir.
+	Report(ctx context.Context, projectId uuid.UUID) *pdf.Document
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/pdf"
+	"task-management/repository"
+)
+
+type ProjectReportServiceImpl struct {
+	ProjectRepository       repository.ProjectRepository
+	TaskRepository          repository.TaskRepository
+	HistoryRepository       repository.TaskStatusHistoryRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	DB                      *sql.DB
+}
+
+func NewProjectReportService(
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	historyRepository repository.TaskStatusHistoryRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	db *sql.DB,
+) ProjectReportService {
+	return &ProjectReportServiceImpl{
+		ProjectRepository:       projectRepository,
+		TaskRepository:          taskRepository,
+		HistoryRepository:       historyRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		DB:                      db,
+	}
+}
+
+func (s *ProjectReportServiceImpl) Report(ctx context.Context, projectId uuid.UUID) *pdf.Document {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, project.Id)
+	helper.PanicIfError(err)
+	histories := s.HistoryRepository.FindByProjectId(ctx, tx, project.Id)
+	members := s.ProjectMemberRepository.FindByProjectId(ctx, tx, project.Id)
+
+	return renderProjectReport(project, tasks, histories, members, helper.Now().In(helper.LocationFromContext(ctx)))
+}
@@ -16,15 +16,29 @@ type ProjectService interface {
 	// Update memperbarui project berdasarkan ID yang diberikan.
 	Update(ctx context.Context, request web.ProjectUpdateRequest) web.ProjectResponse
 
-	// Delete menghapus project berdasarkan ID yang diberikan.
+	// Delete memindahkan project ke trash; bisa di-restore sebelum masa retensi habis.
 	Delete(ctx context.Context, projectId uuid.UUID)
 
 	// FindById mengambil data project berdasarkan ID-nya.
 	FindById(ctx context.Context, projectId uuid.UUID) web.ProjectResponse
 
-	// FindByUserId mengambil semua project yang dimiliki oleh user tertentu.
-	FindByUserId(ctx context.Context, userId uuid.UUID) []web.ProjectResponse
+	// FindByUserId mengambil semua project yang dimiliki user tertentu
+	// atau tempat user tersebut menjadi anggota. Project archived hanya
+	// disertakan jika includeArchived bernilai true.
+	FindByUserId(ctx context.Context, userId uuid.UUID, includeArchived bool) []web.ProjectResponse
 
 	// FindAll mengambil semua project yang ada di sistem.
-	FindAll(ctx context.Context) []web.ProjectResponse
+	FindAll(ctx context.Context, includeArchived bool) []web.ProjectResponse
+
+	// ChangeStatus memindahkan project ke status lifecycle lain sesuai transisi yang diizinkan.
+	ChangeStatus(ctx context.Context, request web.ProjectStatusRequest) web.ProjectResponse
+
+	// Archive mengarsipkan project sehingga menjadi read-only.
+	Archive(ctx context.Context, projectId uuid.UUID) web.ProjectResponse
+
+	// Unarchive mengembalikan project archived ke status sebelum diarsipkan.
+	Unarchive(ctx context.Context, projectId uuid.UUID) web.ProjectResponse
+
+	// Clone menyalin project beserta seluruh task-nya dalam satu transaksi.
+	Clone(ctx context.Context, request web.ProjectCloneRequest) web.ProjectCloneResponse
 }
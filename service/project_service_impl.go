@@ -3,7 +3,12 @@ package service
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/exception"
 	"task-management/helper"
 	"task-management/model/domain"
 	"task-management/model/web"
@@ -11,80 +16,132 @@ import (
 )
 
 type ProjectServiceImpl struct {
-	ProjectRepository repository.ProjectRepository
-	DB                *sql.DB
+	ProjectRepository       repository.ProjectRepository
+	TaskRepository          repository.TaskRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	HistoryRepository       repository.TaskStatusHistoryRepository
+	WatcherRepository       repository.TaskWatcherRepository
+	EventBus                *event.Bus
+	DB                      *sql.DB
+	Validator               *validator.Validate
 }
 
-func NewProjectService(projectRepository repository.ProjectRepository, db *sql.DB) ProjectService {
+func NewProjectService(projectRepository repository.ProjectRepository, taskRepository repository.TaskRepository, projectMemberRepository repository.ProjectMemberRepository, historyRepository repository.TaskStatusHistoryRepository, watcherRepository repository.TaskWatcherRepository, eventBus *event.Bus, db *sql.DB, validator *validator.Validate) ProjectService {
 	return &ProjectServiceImpl{
-		ProjectRepository: projectRepository,
-		DB:                db,
+		ProjectRepository:       projectRepository,
+		TaskRepository:          taskRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		HistoryRepository:       historyRepository,
+		WatcherRepository:       watcherRepository,
+		EventBus:                eventBus,
+		DB:                      db,
+		Validator:               validator,
 	}
 }
 
 func (s *ProjectServiceImpl) Create(ctx context.Context, request web.ProjectCreateRequest) web.ProjectResponse {
-	tx, err := s.DB.Begin()
+	rejectComputedProjectFields(request.Confidence, request.Trend)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
 	project := domain.Project{
-		Name:        request.Name,
-		Description: request.Description,
-		Progress:    request.Progress,
-		Confidence:  request.Confidence,
-		Trend:       request.Trend,
-		UserId:      request.UserId,
+		Name:         request.Name,
+		Description:  request.Description,
+		Progress:     request.Progress,
+		ProgressMode: request.ProgressMode,
+		Trend:        "stable",
+		Status:       request.Status,
+		StartDate:    request.StartDate,
+		DueDate:      request.DueDate,
+	}
+	// Owner project selalu user yang membuatnya
+	project.UserId, _ = helper.UserIdFromContext(ctx)
+	project.WorkspaceId, _ = helper.WorkspaceIdFromContext(ctx)
+
+	if project.Status == "" {
+		project.Status = domain.ProjectStatusActive
+	}
+
+	// Project baru belum punya task, jadi progress otomatis dimulai dari 0
+	if project.ProgressMode != domain.ProgressModeManual {
+		project.ProgressMode = domain.ProgressModeAuto
+		project.Progress = 0
 	}
 
 	project = s.ProjectRepository.Save(ctx, tx, project)
+	addProjectOwner(ctx, tx, s.ProjectMemberRepository, project)
+	publishProjectChange(ctx, tx, s.EventBus, nil, &project)
 
 	return toProjectResponse(project)
 }
 
 func (s *ProjectServiceImpl) Update(ctx context.Context, request web.ProjectUpdateRequest) web.ProjectResponse {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
 	project, err := s.ProjectRepository.FindById(ctx, tx, request.Id)
 	helper.PanicIfError(err)
 
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+	ensureProjectWritable(project)
+	rejectComputedProjectFields(request.Confidence, request.Trend)
+	before := project
+
 	project.Name = request.Name
 	project.Description = request.Description
-	project.Progress = request.Progress
-	project.Confidence = request.Confidence
-	project.Trend = request.Trend
+	if request.ProgressMode != "" {
+		project.ProgressMode = request.ProgressMode
+	}
+	if project.ProgressMode == domain.ProgressModeManual {
+		project.Progress = request.Progress
+	} else {
+		tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, project.Id)
+		helper.PanicIfError(err)
+		project.ProgressMode = domain.ProgressModeAuto
+		project.Progress = calculateProjectProgress(tasks)
+	}
+	project.StartDate = request.StartDate
+	project.DueDate = request.DueDate
 
 	project = s.ProjectRepository.Update(ctx, tx, project)
+	publishProjectChange(ctx, tx, s.EventBus, &before, &project)
 
 	return toProjectResponse(project)
 }
 
 func (s *ProjectServiceImpl) Delete(ctx context.Context, projectId uuid.UUID) {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
-	_, err = s.ProjectRepository.FindById(ctx, tx, projectId)
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
 	helper.PanicIfError(err)
 
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleOwner)
+
 	err = s.ProjectRepository.Delete(ctx, tx, projectId)
 	helper.PanicIfError(err)
+	publishProjectChange(ctx, tx, s.EventBus, &project, nil)
 }
 
 func (s *ProjectServiceImpl) FindById(ctx context.Context, projectId uuid.UUID) web.ProjectResponse {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
 	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
 	helper.PanicIfError(err)
 
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
 	return toProjectResponse(project)
 }
 
-func (s *ProjectServiceImpl) FindByUserId(ctx context.Context, userId uuid.UUID) []web.ProjectResponse {
-	tx, err := s.DB.Begin()
+func (s *ProjectServiceImpl) FindByUserId(ctx context.Context, userId uuid.UUID, includeArchived bool) []web.ProjectResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -92,14 +149,17 @@ func (s *ProjectServiceImpl) FindByUserId(ctx context.Context, userId uuid.UUID)
 
 	var projectResponses []web.ProjectResponse
 	for _, project := range projects {
+		if project.Status == domain.ProjectStatusArchived && !includeArchived {
+			continue
+		}
 		projectResponses = append(projectResponses, toProjectResponse(project))
 	}
 
 	return projectResponses
 }
 
-func (s *ProjectServiceImpl) FindAll(ctx context.Context) []web.ProjectResponse {
-	tx, err := s.DB.Begin()
+func (s *ProjectServiceImpl) FindAll(ctx context.Context, includeArchived bool) []web.ProjectResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
@@ -107,22 +167,202 @@ func (s *ProjectServiceImpl) FindAll(ctx context.Context) []web.ProjectResponse
 
 	var projectResponses []web.ProjectResponse
 	for _, project := range projects {
+		if project.Status == domain.ProjectStatusArchived && !includeArchived {
+			continue
+		}
 		projectResponses = append(projectResponses, toProjectResponse(project))
 	}
 
 	return projectResponses
 }
 
+func (s *ProjectServiceImpl) ChangeStatus(ctx context.Context, request web.ProjectStatusRequest) web.ProjectResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	if request.Status == domain.ProjectStatusArchived {
+		return s.Archive(ctx, request.Id)
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, request.Id)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+	ensureProjectWritable(project)
+
+	if project.Status == request.Status {
+		return toProjectResponse(project)
+	}
+	if !canTransitionProject(project.Status, request.Status) {
+		panic(exception.NewConflictError("cannot change project status from " + project.Status + " to " + request.Status))
+	}
+
+	before := project
+	project.Status = request.Status
+	project = s.ProjectRepository.Update(ctx, tx, project)
+	publishProjectChange(ctx, tx, s.EventBus, &before, &project)
+
+	return toProjectResponse(project)
+}
+
+func (s *ProjectServiceImpl) Archive(ctx context.Context, projectId uuid.UUID) web.ProjectResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+	ensureProjectWritable(project)
+
+	before := project
+	project.ArchivedFromStatus = project.Status
+	project.Status = domain.ProjectStatusArchived
+	project = s.ProjectRepository.Update(ctx, tx, project)
+	publishProjectChange(ctx, tx, s.EventBus, &before, &project)
+
+	return toProjectResponse(project)
+}
+
+func (s *ProjectServiceImpl) Unarchive(ctx context.Context, projectId uuid.UUID) web.ProjectResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+
+	if project.Status != domain.ProjectStatusArchived {
+		panic(exception.NewConflictError("project " + project.Id.String() + " is not archived"))
+	}
+
+	before := project
+	project.Status = project.ArchivedFromStatus
+	if project.Status == "" || project.Status == domain.ProjectStatusArchived {
+		project.Status = domain.ProjectStatusActive
+	}
+	project.ArchivedFromStatus = ""
+	project = s.ProjectRepository.Update(ctx, tx, project)
+	publishProjectChange(ctx, tx, s.EventBus, &before, &project)
+
+	return toProjectResponse(project)
+}
+
+func (s *ProjectServiceImpl) Clone(ctx context.Context, request web.ProjectCloneRequest) web.ProjectCloneResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	source, err := s.ProjectRepository.FindById(ctx, tx, request.Id)
+	helper.PanicIfError(err)
+
+	// Clone menyalin seluruh isi project, jadi viewer tidak cukup
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, source, domain.ProjectRoleMember)
+	userId, _ := helper.UserIdFromContext(ctx)
+	if request.UserId != nil && *request.UserId != userId {
+		panic(exception.NewBadRequestError("user_id must be the current user, transfer ownership after cloning"))
+	}
+
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, source.Id)
+	helper.PanicIfError(err)
+
+	project := source
+	project.Id = uuid.Nil
+	project.Name = source.Name + " (copy)"
+	// Hasil clone dari project archived kembali ke status sebelum diarsipkan
+	if project.Status == domain.ProjectStatusArchived {
+		project.Status = source.ArchivedFromStatus
+		if project.Status == "" {
+			project.Status = domain.ProjectStatusActive
+		}
+	}
+	project.ArchivedFromStatus = ""
+	if request.Name != "" {
+		project.Name = request.Name
+	}
+	// Owner hasil clone selalu user yang melakukan clone
+	project.UserId = userId
+	if request.ResetProgress {
+		project.Progress = 0
+	}
+	project.StartDate = shiftDate(source.StartDate, request.ShiftDays)
+	project.DueDate = shiftDate(source.DueDate, request.ShiftDays)
+
+	project = s.ProjectRepository.Save(ctx, tx, project)
+	addProjectOwner(ctx, tx, s.ProjectMemberRepository, project)
+
+	taskIdMap := make(map[uuid.UUID]uuid.UUID, len(tasks))
+	for _, task := range tasks {
+		oldId := task.Id
+		task.Id = uuid.New()
+		task.ProjectId = project.Id
+		if request.ResetStatus {
+			task.Status = "todo"
+		}
+		if request.ResetProgress {
+			task.Progress = ""
+			task.ContinueTomorrow = false
+		}
+		task.DueDate = shiftDate(task.DueDate, request.ShiftDays)
+		task.CreatedAt = helper.Now()
+		task.UpdatedAt = task.CreatedAt
+
+		_, err = saveNewTask(ctx, tx, s.TaskRepository, s.HistoryRepository, s.WatcherRepository, s.EventBus, task)
+		helper.PanicIfError(err)
+		taskIdMap[oldId] = task.Id
+	}
+
+	if request.ResetStatus || request.ResetProgress {
+		recalculateProjectProgress(ctx, tx, s.ProjectRepository, s.TaskRepository, project.Id)
+		project, err = s.ProjectRepository.FindById(ctx, tx, project.Id)
+		helper.PanicIfError(err)
+	}
+	publishProjectChange(ctx, tx, s.EventBus, nil, &project)
+
+	return web.ProjectCloneResponse{
+		Project:   toProjectResponse(project),
+		TaskIdMap: taskIdMap,
+	}
+}
+
+// rejectComputedProjectFields menolak confidence dan trend dari klien karena
+// keduanya dihitung dari snapshot harian
+func rejectComputedProjectFields(confidence *float64, trend *string) {
+	if confidence != nil || trend != nil {
+		panic(exception.NewBadRequestError("confidence and trend are computed from daily snapshots and cannot be set"))
+	}
+}
+
+// shiftDate menggeser date sejumlah hari (nil tetap nil)
+func shiftDate(date *time.Time, days int) *time.Time {
+	if date == nil {
+		return nil
+	}
+	shifted := date.AddDate(0, 0, days)
+	return &shifted
+}
+
 func toProjectResponse(project domain.Project) web.ProjectResponse {
 	return web.ProjectResponse{
-		Id:          project.Id,
-		Name:        project.Name,
-		Description: project.Description,
-		Progress:    project.Progress,
-		Confidence:  project.Confidence,
-		Trend:       project.Trend,
-		CreatedAt:   project.CreatedAt,
-		UpdatedAt:   project.UpdatedAt,
-		UserId:      project.UserId,
+		Id:           project.Id,
+		Name:         project.Name,
+		Description:  project.Description,
+		Progress:     project.Progress,
+		ProgressMode: project.ProgressMode,
+		Confidence:   project.Confidence,
+		Trend:        project.Trend,
+		Status:       project.Status,
+		StartDate:    project.StartDate,
+		DueDate:      project.DueDate,
+		CreatedAt:    project.CreatedAt,
+		UpdatedAt:    project.UpdatedAt,
+		UserId:       project.UserId,
 	}
 }
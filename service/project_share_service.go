@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+// ProjectShareService mengelola share link publik (read-only) untuk project.
+type ProjectShareService interface {
+	// Create membuat share link baru yang berlaku sampai waktu tertentu.
+	Create(ctx context.Context, request web.ProjectShareLinkCreateRequest) web.ProjectShareLinkResponse
+
+	// FindByProjectId mengambil semua share link project, termasuk yang sudah kedaluwarsa/di-revoke.
+	FindByProjectId(ctx context.Context, projectId uuid.UUID) []web.ProjectShareLinkResponse
+
+	// Revoke mematikan share link sebelum masa berlakunya habis.
+	Revoke(ctx context.Context, projectId uuid.UUID, linkId uuid.UUID) web.ProjectShareLinkResponse
+
+	// FindShared mengambil ringkasan project dan task-nya lewat token share link, tanpa login.
+	FindShared(ctx context.Context, token string) web.SharedProjectResponse
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+// defaultShareLinkHours adalah masa berlaku share link jika tidak diisi (7 hari)
+const defaultShareLinkHours = 7 * 24
+
+type ProjectShareServiceImpl struct {
+	ProjectShareLinkRepository repository.ProjectShareLinkRepository
+	ProjectRepository          repository.ProjectRepository
+	TaskRepository             repository.TaskRepository
+	ProjectMemberRepository    repository.ProjectMemberRepository
+	DB                         *sql.DB
+	Validator                  *validator.Validate
+	// Secret untuk menandatangani token, BaseUrl untuk menyusun URL publik
+	Secret  []byte
+	BaseUrl string
+}
+
+func NewProjectShareService(
+	projectShareLinkRepository repository.ProjectShareLinkRepository,
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	db *sql.DB,
+	validator *validator.Validate,
+	secret []byte,
+	baseUrl string,
+) ProjectShareService {
+	return &ProjectShareServiceImpl{
+		ProjectShareLinkRepository: projectShareLinkRepository,
+		ProjectRepository:          projectRepository,
+		TaskRepository:             taskRepository,
+		ProjectMemberRepository:    projectMemberRepository,
+		DB:                         db,
+		Validator:                  validator,
+		Secret:                     secret,
+		BaseUrl:                    baseUrl,
+	}
+}
+
+func (s *ProjectShareServiceImpl) Create(ctx context.Context, request web.ProjectShareLinkCreateRequest) web.ProjectShareLinkResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	userId, ok := helper.UserIdFromContext(ctx)
+	if !ok {
+		panic(exception.NewForbiddenError("user not found in context"))
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, request.ProjectId)
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+
+	hours := request.ExpiresInHours
+	if hours == 0 {
+		hours = defaultShareLinkHours
+	}
+	link := s.ProjectShareLinkRepository.Save(ctx, tx, domain.ProjectShareLink{
+		ProjectId: project.Id,
+		CreatedBy: userId,
+		// Token menyimpan detik, jadi presisi di bawahnya dibuang
+		ExpiresAt: helper.Now().Add(time.Duration(hours) * time.Hour).Truncate(time.Second),
+	})
+
+	return s.toShareLinkResponse(link)
+}
+
+func (s *ProjectShareServiceImpl) FindByProjectId(ctx context.Context, projectId uuid.UUID) []web.ProjectShareLinkResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, projectId)
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+
+	var linkResponses []web.ProjectShareLinkResponse
+	for _, link := range s.ProjectShareLinkRepository.FindByProjectId(ctx, tx, projectId) {
+		linkResponses = append(linkResponses, s.toShareLinkResponse(link))
+	}
+	return linkResponses
+}
+
+func (s *ProjectShareServiceImpl) Revoke(ctx context.Context, projectId uuid.UUID, linkId uuid.UUID) web.ProjectShareLinkResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := s.findProject(ctx, tx, projectId)
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+
+	link, err := s.ProjectShareLinkRepository.FindById(ctx, tx, linkId)
+	if err != nil || link.ProjectId != project.Id {
+		panic(exception.NewNotFoundError("share link not found"))
+	}
+	link = s.ProjectShareLinkRepository.Revoke(ctx, tx, link)
+
+	return s.toShareLinkResponse(link)
+}
+
+func (s *ProjectShareServiceImpl) FindShared(ctx context.Context, token string) web.SharedProjectResponse {
+	linkId, err := helper.ParseShareToken(s.Secret, token, helper.Now())
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	// Request publik tidak punya workspace; token yang valid sudah cukup
+	// sebagai izin membaca satu project ini saja.
+	ctx = helper.ContextWithSystem(ctx)
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	link, err := s.ProjectShareLinkRepository.FindById(ctx, tx, linkId)
+	if err != nil || link.RevokedAt != nil {
+		panic(exception.NewNotFoundError(helper.ErrInvalidShareToken.Error()))
+	}
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, link.ProjectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(helper.ErrInvalidShareToken.Error()))
+	}
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, project.Id)
+	helper.PanicIfError(err)
+
+	sharedResponse := web.SharedProjectResponse{
+		Name:        project.Name,
+		Description: project.Description,
+		Status:      project.Status,
+		Progress:    project.Progress,
+		StartDate:   project.StartDate,
+		DueDate:     project.DueDate,
+		UpdatedAt:   project.UpdatedAt,
+		ExpiresAt:   link.ExpiresAt,
+		Tasks:       []web.SharedTaskResponse{},
+	}
+	for _, task := range tasks {
+		sharedResponse.Tasks = append(sharedResponse.Tasks, web.SharedTaskResponse{
+			Title:       task.Title,
+			Status:      task.Status,
+			Priority:    task.Priority,
+			Effort:      task.Effort,
+			Deliverable: task.Deliverable,
+			Progress:    task.Progress,
+			Labels:      task.Labels,
+			DueDate:     task.DueDate,
+		})
+	}
+	return sharedResponse
+}
+
+func (s *ProjectShareServiceImpl) findProject(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) domain.Project {
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return project
+}
+
+func (s *ProjectShareServiceImpl) toShareLinkResponse(link domain.ProjectShareLink) web.ProjectShareLinkResponse {
+	token := helper.SignShareToken(s.Secret, link.Id, link.ExpiresAt)
+	return web.ProjectShareLinkResponse{
+		Id:        link.Id,
+		ProjectId: link.ProjectId,
+		Token:     token,
+		Url:       s.BaseUrl + "/public/share/" + token,
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedBy: link.CreatedBy,
+		CreatedAt: link.CreatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+// ProjectSnapshotService mencatat kondisi harian project dan menurunkan
+// Trend serta Confidence dari deret snapshot tersebut.
+type ProjectSnapshotService interface {
+	// TakeSnapshots menyimpan snapshot hari ini untuk semua project lalu
+	// memperbarui Trend dan Confidence masing-masing project.
+	TakeSnapshots(ctx context.Context)
+
+	// FindByProjectId mengambil deret snapshot project di rentang tanggal [from, to].
+	FindByProjectId(ctx context.Context, projectId uuid.UUID, from time.Time, to time.Time) []web.ProjectSnapshotResponse
+}
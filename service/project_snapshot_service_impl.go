@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+const (
+	// trendWindowDays adalah jumlah hari snapshot terakhir yang dipakai untuk menghitung slope
+	trendWindowDays = 7
+	// trendThreshold adalah perubahan progress minimal (poin per hari) agar dianggap naik/turun
+	trendThreshold = 0.1
+)
+
+type ProjectSnapshotServiceImpl struct {
+	ProjectSnapshotRepository repository.ProjectSnapshotRepository
+	ProjectRepository         repository.ProjectRepository
+	TaskRepository            repository.TaskRepository
+	ProjectMemberRepository   repository.ProjectMemberRepository
+	ProfileRepository         repository.ProfileRepository
+	DB                        *sql.DB
+}
+
+func NewProjectSnapshotService(
+	projectSnapshotRepository repository.ProjectSnapshotRepository,
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	profileRepository repository.ProfileRepository,
+	db *sql.DB,
+) ProjectSnapshotService {
+	return &ProjectSnapshotServiceImpl{
+		ProjectSnapshotRepository: projectSnapshotRepository,
+		ProjectRepository:         projectRepository,
+		TaskRepository:            taskRepository,
+		ProjectMemberRepository:   projectMemberRepository,
+		ProfileRepository:         profileRepository,
+		DB:                        db,
+	}
+}
+
+// TakeSnapshots memproses setiap project secara terpisah: project yang gagal
+// dicatat dan dilewati tanpa menghentikan project lain
+func (s *ProjectSnapshotServiceImpl) TakeSnapshots(ctx context.Context) {
+	for _, project := range s.findProjects(ctx) {
+		// Project archived read-only, kondisinya tidak berubah lagi
+		if project.Status == domain.ProjectStatusArchived {
+			continue
+		}
+		if err := s.takeSnapshot(ctx, project.Id); err != nil {
+			fmt.Printf("⚠️ Snapshot for project %s failed: %s\n", project.Id, err)
+		}
+	}
+}
+
+func (s *ProjectSnapshotServiceImpl) findProjects(ctx context.Context) []domain.Project {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return s.ProjectRepository.FindAll(ctx, tx)
+}
+
+// takeSnapshot memproses satu project dalam transaksinya sendiri; panic
+// dikembalikan sebagai error setelah transaksinya di-rollback
+func (s *ProjectSnapshotServiceImpl) takeSnapshot(ctx context.Context, projectId uuid.UUID) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+	// Status bisa berubah sejak daftar project dibaca
+	if project.Status == domain.ProjectStatusArchived {
+		return nil
+	}
+
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	// Snapshot harian mengikuti "hari ini" di zona waktu pemilik project
+	today := helper.StartOfDay(helper.Now(), helper.LoadLocation(profileTimezone(ctx, tx, s.ProfileRepository, project.UserId)))
+	snapshot := domain.ProjectSnapshot{
+		ProjectId:    projectId,
+		SnapshotDate: today,
+		Progress:     project.Progress,
+	}
+	for _, task := range tasks {
+		snapshot.TotalEffort += task.Effort
+		if task.Status == "completed" {
+			snapshot.CompletedEffort += task.Effort
+		} else {
+			snapshot.OpenTasks++
+		}
+	}
+	s.ProjectSnapshotRepository.Upsert(ctx, tx, snapshot)
+
+	snapshots := s.ProjectSnapshotRepository.FindByProjectId(ctx, tx, projectId, today.AddDate(0, 0, -(trendWindowDays-1)), today)
+	slope, ok := progressSlope(snapshots)
+	if !ok {
+		return nil
+	}
+
+	project.Trend = trendFromSlope(slope)
+	project.Confidence = confidenceFromProjection(project, slope, today)
+	s.ProjectRepository.Update(ctx, tx, project)
+	return nil
+}
+
+func (s *ProjectSnapshotServiceImpl) FindByProjectId(ctx context.Context, projectId uuid.UUID, from time.Time, to time.Time) []web.ProjectSnapshotResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+
+	snapshots := s.ProjectSnapshotRepository.FindByProjectId(ctx, tx, projectId, from, to)
+
+	var snapshotResponses []web.ProjectSnapshotResponse
+	for _, snapshot := range snapshots {
+		snapshotResponses = append(snapshotResponses, web.ProjectSnapshotResponse{
+			ProjectId:       snapshot.ProjectId,
+			SnapshotDate:    snapshot.SnapshotDate.Format("2006-01-02"),
+			Progress:        snapshot.Progress,
+			OpenTasks:       snapshot.OpenTasks,
+			CompletedEffort: snapshot.CompletedEffort,
+			TotalEffort:     snapshot.TotalEffort,
+			CreatedAt:       snapshot.CreatedAt,
+		})
+	}
+
+	return snapshotResponses
+}
+
+// progressSlope menghitung slope regresi linear progress terhadap hari
+// (poin progress per hari). Butuh minimal dua snapshot.
+func progressSlope(snapshots []domain.ProjectSnapshot) (float64, bool) {
+	if len(snapshots) < 2 {
+		return 0, false
+	}
+
+	origin := snapshots[0].SnapshotDate
+	n := float64(len(snapshots))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, snapshot := range snapshots {
+		x := snapshot.SnapshotDate.Sub(origin).Hours() / 24
+		y := snapshot.Progress
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denominator, true
+}
+
+func trendFromSlope(slope float64) string {
+	switch {
+	case slope > trendThreshold:
+		return "up"
+	case slope < -trendThreshold:
+		return "down"
+	default:
+		return "stable"
+	}
+}
+
+// confidenceFromProjection membandingkan perkiraan tanggal selesai (dengan laju
+// progress saat ini) terhadap due date project, hasilnya 0-100.
+// Project tanpa due date mempertahankan confidence yang lama.
+func confidenceFromProjection(project domain.Project, slope float64, today time.Time) float64 {
+	if project.Progress >= 100 {
+		return 100
+	}
+	if project.DueDate == nil {
+		return project.Confidence
+	}
+
+	daysLeft := helper.StartOfDay(*project.DueDate, today.Location()).Sub(today).Hours() / 24
+	if daysLeft < 0 {
+		return 0
+	}
+	if slope <= 0 {
+		// Tidak ada kemajuan: hampir pasti tidak selesai tepat waktu
+		return 10
+	}
+
+	daysNeeded := (100 - project.Progress) / slope
+	confidence := math.Min(daysLeft/daysNeeded, 1) * 100
+	return math.Round(confidence*100) / 100
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+// ProjectTemplateService mendefinisikan kontrak bisnis logic
+// untuk template project.
+type ProjectTemplateService interface {
+	// Create menyimpan project yang sudah ada (beserta task-nya) sebagai template.
+	Create(ctx context.Context, request web.ProjectTemplateCreateRequest) web.ProjectTemplateResponse
+
+	// Instantiate membuat project baru beserta task-nya dari template.
+	Instantiate(ctx context.Context, request web.ProjectFromTemplateRequest) web.ProjectResponse
+
+	// Delete menghapus template berdasarkan ID yang diberikan.
+	Delete(ctx context.Context, templateId uuid.UUID)
+
+	// FindById mengambil template beserta task-nya.
+	FindById(ctx context.Context, templateId uuid.UUID) web.ProjectTemplateResponse
+
+	// FindAll mengambil semua template (tanpa task).
+	FindAll(ctx context.Context) []web.ProjectTemplateResponse
+}
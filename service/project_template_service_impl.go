@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+type ProjectTemplateServiceImpl struct {
+	ProjectTemplateRepository repository.ProjectTemplateRepository
+	ProjectRepository         repository.ProjectRepository
+	TaskRepository            repository.TaskRepository
+	ProjectMemberRepository   repository.ProjectMemberRepository
+	HistoryRepository         repository.TaskStatusHistoryRepository
+	WatcherRepository         repository.TaskWatcherRepository
+	EventBus                  *event.Bus
+	DB                        *sql.DB
+	Validator                 *validator.Validate
+}
+
+func NewProjectTemplateService(
+	projectTemplateRepository repository.ProjectTemplateRepository,
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	historyRepository repository.TaskStatusHistoryRepository,
+	watcherRepository repository.TaskWatcherRepository,
+	eventBus *event.Bus,
+	db *sql.DB,
+	validator *validator.Validate,
+) ProjectTemplateService {
+	return &ProjectTemplateServiceImpl{
+		ProjectTemplateRepository: projectTemplateRepository,
+		ProjectRepository:         projectRepository,
+		TaskRepository:            taskRepository,
+		ProjectMemberRepository:   projectMemberRepository,
+		HistoryRepository:         historyRepository,
+		WatcherRepository:         watcherRepository,
+		EventBus:                  eventBus,
+		DB:                        db,
+		Validator:                 validator,
+	}
+}
+
+func (s *ProjectTemplateServiceImpl) Create(ctx context.Context, request web.ProjectTemplateCreateRequest) web.ProjectTemplateResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, request.ProjectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleViewer)
+	userId, _ := helper.UserIdFromContext(ctx)
+
+	tasks, err := s.TaskRepository.FindByProjectId(ctx, tx, project.Id)
+	helper.PanicIfError(err)
+
+	// Tanggal disimpan relatif terhadap tanggal mulai project
+	base := projectBaseDate(project)
+
+	template := domain.ProjectTemplate{
+		Name:            request.Name,
+		Description:     request.Description,
+		SourceProjectId: &project.Id,
+		DurationDays:    daysBetween(base, project.DueDate),
+		UserId:          userId,
+		Shared:          request.Shared,
+		WorkspaceId:     project.WorkspaceId,
+	}
+	for i, task := range tasks {
+		template.Tasks = append(template.Tasks, domain.ProjectTemplateTask{
+			Position:        i,
+			Title:           task.Title,
+			Priority:        task.Priority,
+			Effort:          task.Effort,
+			DifficultyLevel: task.DifficultyLevel,
+			Deliverable:     task.Deliverable,
+			Labels:          task.Labels,
+			DueOffsetDays:   daysBetween(base, task.DueDate),
+		})
+	}
+
+	template = s.ProjectTemplateRepository.Save(ctx, tx, template)
+
+	return toProjectTemplateResponse(template)
+}
+
+func (s *ProjectTemplateServiceImpl) Instantiate(ctx context.Context, request web.ProjectFromTemplateRequest) web.ProjectResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	template := s.findTemplate(ctx, tx, request.TemplateId)
+	// Owner project baru selalu user yang membuatnya, sama seperti clone
+	userId, _ := helper.UserIdFromContext(ctx)
+
+	// Tanggal relatif dihitung di zona waktu user agar tidak bergeser satu hari
+	location := helper.LocationFromContext(ctx)
+	startDate := helper.Now().In(location)
+	if request.StartDate != nil {
+		startDate = request.StartDate.In(location)
+	}
+
+	// Placeholder bawaan bisa ditimpa oleh variables dari request
+	variables := map[string]string{
+		"start_date": startDate.Format("2006-01-02"),
+	}
+	for key, value := range request.Variables {
+		variables[key] = value
+	}
+	projectName := substitutePlaceholders(request.Name, variables)
+	if _, ok := request.Variables["project_name"]; !ok {
+		variables["project_name"] = projectName
+	}
+
+	project := domain.Project{
+		Name:         projectName,
+		Description:  substitutePlaceholders(request.Description, variables),
+		ProgressMode: domain.ProgressModeAuto,
+		Trend:        "stable",
+		Status:       domain.ProjectStatusActive,
+		StartDate:    &startDate,
+		DueDate:      addDays(startDate, template.DurationDays),
+		UserId:       userId,
+		WorkspaceId:  template.WorkspaceId,
+	}
+	project = s.ProjectRepository.Save(ctx, tx, project)
+	addProjectOwner(ctx, tx, s.ProjectMemberRepository, project)
+
+	for _, templateTask := range template.Tasks {
+		task := domain.Task{
+			Id:              uuid.New(),
+			ProjectId:       project.Id,
+			Title:           substitutePlaceholders(templateTask.Title, variables),
+			Status:          "todo",
+			Priority:        templateTask.Priority,
+			Effort:          templateTask.Effort,
+			DifficultyLevel: templateTask.DifficultyLevel,
+			Deliverable:     templateTask.Deliverable,
+			Labels:          templateTask.Labels,
+			DueDate:         addDays(startDate, templateTask.DueOffsetDays),
+		}
+		_, err = saveNewTask(ctx, tx, s.TaskRepository, s.HistoryRepository, s.WatcherRepository, s.EventBus, task)
+		helper.PanicIfError(err)
+	}
+
+	recalculateProjectProgress(ctx, tx, s.ProjectRepository, s.TaskRepository, project.Id)
+	project, err = s.ProjectRepository.FindById(ctx, tx, project.Id)
+	helper.PanicIfError(err)
+	publishProjectChange(ctx, tx, s.EventBus, nil, &project)
+
+	return toProjectResponse(project)
+}
+
+func (s *ProjectTemplateServiceImpl) Delete(ctx context.Context, templateId uuid.UUID) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	template := s.findTemplate(ctx, tx, templateId)
+	if userId, _ := helper.UserIdFromContext(ctx); template.UserId != userId {
+		panic(exception.NewForbiddenError("only the creator of a template can delete it"))
+	}
+
+	err = s.ProjectTemplateRepository.Delete(ctx, tx, templateId)
+	helper.PanicIfError(err)
+}
+
+func (s *ProjectTemplateServiceImpl) FindById(ctx context.Context, templateId uuid.UUID) web.ProjectTemplateResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return toProjectTemplateResponse(s.findTemplate(ctx, tx, templateId))
+}
+
+func (s *ProjectTemplateServiceImpl) FindAll(ctx context.Context) []web.ProjectTemplateResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	templates := s.ProjectTemplateRepository.FindAll(ctx, tx)
+
+	var templateResponses []web.ProjectTemplateResponse
+	for _, template := range templates {
+		if !canUseTemplate(ctx, template) {
+			continue
+		}
+		templateResponses = append(templateResponses, toProjectTemplateResponse(template))
+	}
+
+	return templateResponses
+}
+
+// findTemplate memuat template yang boleh dipakai user. Template milik user lain
+// yang tidak di-share dianggap tidak ada agar keberadaannya tidak bocor.
+func (s *ProjectTemplateServiceImpl) findTemplate(ctx context.Context, tx *sql.Tx, templateId uuid.UUID) domain.ProjectTemplate {
+	template, err := s.ProjectTemplateRepository.FindById(ctx, tx, templateId)
+	if err != nil || !canUseTemplate(ctx, template) {
+		panic(exception.NewNotFoundError("project template not found"))
+	}
+	return template
+}
+
+// canUseTemplate bernilai true untuk template milik user sendiri atau yang di-share
+func canUseTemplate(ctx context.Context, template domain.ProjectTemplate) bool {
+	userId, ok := helper.UserIdFromContext(ctx)
+	return template.Shared || (ok && template.UserId == userId)
+}
+
+// projectBaseDate adalah titik nol untuk tanggal relatif: start date project,
+// atau tanggal dibuat jika start date kosong.
+func projectBaseDate(project domain.Project) time.Time {
+	if project.StartDate != nil {
+		return *project.StartDate
+	}
+	return project.CreatedAt
+}
+
+// daysBetween menghitung selisih hari dari base ke date (nil jika date kosong)
+func daysBetween(base time.Time, date *time.Time) *int {
+	if date == nil {
+		return nil
+	}
+	days := int(date.Sub(base).Round(24*time.Hour) / (24 * time.Hour))
+	return &days
+}
+
+// addDays mengembalikan base + days hari (nil jika days kosong)
+func addDays(base time.Time, days *int) *time.Time {
+	if days == nil {
+		return nil
+	}
+	date := base.AddDate(0, 0, *days)
+	return &date
+}
+
+// substitutePlaceholders mengganti {{key}} dengan nilai dari variables.
+// Placeholder yang tidak dikenal dibiarkan apa adanya.
+func substitutePlaceholders(text string, variables map[string]string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+	pairs := make([]string, 0, len(variables)*2)
+	for key, value := range variables {
+		pairs = append(pairs, "{{"+key+"}}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+func toProjectTemplateResponse(template domain.ProjectTemplate) web.ProjectTemplateResponse {
+	response := web.ProjectTemplateResponse{
+		Id:              template.Id,
+		Name:            template.Name,
+		Description:     template.Description,
+		SourceProjectId: template.SourceProjectId,
+		DurationDays:    template.DurationDays,
+		UserId:          template.UserId,
+		Shared:          template.Shared,
+		CreatedAt:       template.CreatedAt,
+		UpdatedAt:       template.UpdatedAt,
+	}
+	for _, task := range template.Tasks {
+		response.Tasks = append(response.Tasks, web.ProjectTemplateTaskResponse{
+			Id:              task.Id,
+			Position:        task.Position,
+			Title:           task.Title,
+			Priority:        task.Priority,
+			Effort:          task.Effort,
+			DifficultyLevel: task.DifficultyLevel,
+			Deliverable:     task.Deliverable,
+			Labels:          task.Labels,
+			DueOffsetDays:   task.DueOffsetDays,
+		})
+	}
+	return response
+}
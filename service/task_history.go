@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"task-management/model/domain"
+	"task-management/repository"
+)
+
+// recordTaskChange mencatat perubahan task ke riwayat status. before nil berarti
+// task baru dibuat, after nil berarti task dihapus. Perpindahan project dicatat
+// sebagai keluar dari project lama dan masuk ke project baru.
+func recordTaskChange(ctx context.Context, tx *sql.Tx, historyRepository repository.TaskStatusHistoryRepository, before *domain.Task, after *domain.Task) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		saveTaskHistory(ctx, tx, historyRepository, *after, "", after.Status)
+	case after == nil:
+		saveTaskHistory(ctx, tx, historyRepository, *before, before.Status, domain.TaskStatusDeleted)
+	case before.ProjectId != after.ProjectId:
+		saveTaskHistory(ctx, tx, historyRepository, *before, before.Status, domain.TaskStatusDeleted)
+		saveTaskHistory(ctx, tx, historyRepository, *after, "", after.Status)
+	case before.Status != after.Status || before.Effort != after.Effort:
+		saveTaskHistory(ctx, tx, historyRepository, *after, before.Status, after.Status)
+	}
+}
+
+func saveTaskHistory(ctx context.Context, tx *sql.Tx, historyRepository repository.TaskStatusHistoryRepository, task domain.Task, fromStatus string, toStatus string) {
+	historyRepository.Save(ctx, tx, domain.TaskStatusHistory{
+		TaskId:     task.Id,
+		ProjectId:  task.ProjectId,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Effort:     task.Effort,
+	})
+}
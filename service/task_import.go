@@ -0,0 +1,206 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/web"
+)
+
+// taskImportMaxRows membatasi jumlah baris data dalam satu file import
+const taskImportMaxRows = 5000
+
+// taskImportFields adalah field web.TaskCreateRequest yang bisa diisi dari CSV
+var taskImportFields = []string{
+	"title", "status", "priority", "effort", "difficulty_level", "deliverable",
+	"bottleneck", "assignee_id", "labels", "due_date",
+}
+
+// taskImportReader membaca baris CSV import dan mengubahnya menjadi TaskCreateRequest
+type taskImportReader struct {
+	reader *csv.Reader
+	// columns memetakan field task ke indeks kolom CSV
+	columns map[string]int
+}
+
+// newTaskImportReader membaca header CSV lalu mencocokkan kolom dengan field
+// task. Header yang tidak valid membatalkan seluruh import dengan BadRequestError.
+func newTaskImportReader(file io.Reader, mapping map[string]string) *taskImportReader {
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		panic(exception.NewBadRequestError("CSV header could not be read: " + err.Error()))
+	}
+	if len(header) > 0 {
+		// Excel menulis BOM UTF-8 di awal file
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	headerIndex := map[string]int{}
+	for i, name := range header {
+		headerIndex[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for field := range mapping {
+		if !slices.Contains(taskImportFields, field) {
+			panic(exception.NewBadRequestError("unknown task field in mapping: " + field + " (allowed: " + strings.Join(taskImportFields, ", ") + ")"))
+		}
+	}
+
+	columns := map[string]int{}
+	for _, field := range taskImportFields {
+		column, mapped := mapping[field]
+		if !mapped {
+			column = field
+		}
+		index, found := headerIndex[strings.ToLower(strings.TrimSpace(column))]
+		if !found {
+			if mapped {
+				panic(exception.NewBadRequestError("column " + strconv.Quote(column) + " mapped to " + field + " is not in the CSV header"))
+			}
+			continue
+		}
+		columns[field] = index
+	}
+	if _, found := columns["title"]; !found {
+		panic(exception.NewBadRequestError("CSV has no column for title; add a \"title\" column or map one"))
+	}
+
+	return &taskImportReader{reader: reader, columns: columns}
+}
+
+// next membaca baris data berikutnya beserta nomor barisnya di file. Baris yang
+// semua selnya kosong dilewati. io.EOF menandakan file sudah habis; baris dengan
+// jumlah kolom berbeda dari header dikembalikan sebagai error baris itu saja.
+func (r *taskImportReader) next() (record []string, line int, err error) {
+	for {
+		record, err = r.reader.Read()
+		if err == io.EOF {
+			return nil, 0, err
+		}
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			if errors.Is(parseError.Err, csv.ErrFieldCount) {
+				return nil, parseError.StartLine, errors.New(parseError.Err.Error())
+			}
+			panic(exception.NewBadRequestError("invalid CSV: " + err.Error()))
+		}
+		helper.PanicIfError(err)
+
+		line, _ = r.reader.FieldPos(0)
+		if strings.TrimSpace(strings.Join(record, "")) != "" {
+			return record, line, nil
+		}
+	}
+}
+
+// parse mengisi request dari satu baris CSV. assigneeByEmail dipakai jika kolom
+// assignee_id berisi email anggota project alih-alih ID user.
+func (r *taskImportReader) parse(record []string, request *web.TaskCreateRequest, assigneeByEmail func(email string) (uuid.UUID, bool)) []string {
+	var rowErrors []string
+	value := func(field string) string {
+		index, found := r.columns[field]
+		if !found {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	request.Title = helper.UnescapeCsvText(value("title"))
+	request.Status = strings.ToLower(value("status"))
+	request.Priority = strings.ToLower(value("priority"))
+	request.DifficultyLevel = helper.UnescapeCsvText(value("difficulty_level"))
+	request.Deliverable = helper.UnescapeCsvText(value("deliverable"))
+	request.Bottleneck = helper.UnescapeCsvText(value("bottleneck"))
+
+	if effort := value("effort"); effort != "" {
+		parsed, err := strconv.Atoi(effort)
+		if err != nil {
+			rowErrors = append(rowErrors, "effort: must be an integer")
+		}
+		request.Effort = parsed
+	}
+
+	if assignee := value("assignee_id"); assignee != "" {
+		if assigneeId, err := uuid.Parse(assignee); err == nil {
+			request.AssigneeId = &assigneeId
+		} else if assigneeId, found := assigneeByEmail(assignee); found {
+			request.AssigneeId = &assigneeId
+		} else {
+			rowErrors = append(rowErrors, "assignee_id: must be a user ID or the email of a project member")
+		}
+	}
+
+	for _, label := range strings.Split(helper.UnescapeCsvText(value("labels")), helper.TaskCsvLabelSeparator) {
+		if label = strings.TrimSpace(label); label != "" {
+			request.Labels = append(request.Labels, label)
+		}
+	}
+
+	if dueDate := value("due_date"); dueDate != "" {
+		parsed, err := time.Parse(time.RFC3339, dueDate)
+		if err != nil {
+			// Tanggal tanpa jam disimpan sebagai tengah malam UTC
+			parsed, err = time.Parse("2006-01-02", dueDate)
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, "due_date: must be RFC 3339 (2006-01-02T15:04:05Z) or YYYY-MM-DD")
+		} else {
+			request.DueDate = &parsed
+		}
+	}
+
+	return rowErrors
+}
+
+// validationMessages mengubah error validator menjadi pesan per field dengan nama field JSON
+func validationMessages(err error, request interface{}) []string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []string{err.Error()}
+	}
+
+	requestType := reflect.TypeOf(request)
+	var messages []string
+	for _, fieldError := range validationErrors {
+		name := fieldError.Field()
+		if field, found := requestType.FieldByName(fieldError.StructField()); found {
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+				name = tag
+			}
+		}
+		if fieldError.Param() != "" {
+			messages = append(messages, fmt.Sprintf("%s: failed on '%s' validation (%s)", name, fieldError.Tag(), fieldError.Param()))
+		} else {
+			messages = append(messages, fmt.Sprintf("%s: failed on '%s' validation", name, fieldError.Tag()))
+		}
+	}
+	return messages
+}
+
+// appendFieldErrors menambahkan pesan validasi untuk field yang belum punya
+// error parsing, agar satu nilai yang salah tidak dilaporkan dua kali
+func appendFieldErrors(rowErrors []string, messages []string) []string {
+	reported := map[string]bool{}
+	for _, rowError := range rowErrors {
+		field, _, _ := strings.Cut(rowError, ":")
+		reported[field] = true
+	}
+	for _, message := range messages {
+		field, _, _ := strings.Cut(message, ":")
+		if !reported[field] {
+			rowErrors = append(rowErrors, message)
+		}
+	}
+	return rowErrors
+}
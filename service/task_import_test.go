@@ -0,0 +1,86 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+func TestTaskImportReaderParse(t *testing.T) {
+	memberId := uuid.New()
+	assigneeByEmail := func(email string) (uuid.UUID, bool) {
+		return memberId, email == "budi@example.com"
+	}
+
+	tests := []struct {
+		name       string
+		csv        string
+		mapping    map[string]string
+		want       web.TaskCreateRequest
+		wantErrors int
+	}{
+		{
+			name: "exported columns",
+			csv:  "\ufefftitle,status,effort,labels,due_date\n'=SUM(A1),TODO,3,\"a, b\",2026-03-10\n",
+			want: web.TaskCreateRequest{
+				Title:   "=SUM(A1)",
+				Status:  "todo",
+				Effort:  3,
+				Labels:  []string{"a", "b"},
+				DueDate: timePtr(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:    "mapped columns and assignee email",
+			csv:     "Judul,PIC\nRapat,budi@example.com\n",
+			mapping: map[string]string{"title": "Judul", "assignee_id": "PIC"},
+			want:    web.TaskCreateRequest{Title: "Rapat", AssigneeId: &memberId},
+		},
+		{
+			name:       "invalid cells",
+			csv:        "title,effort,assignee_id,due_date\nRapat,tiga,ani@example.com,besok\n",
+			want:       web.TaskCreateRequest{Title: "Rapat"},
+			wantErrors: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader := newTaskImportReader(strings.NewReader(test.csv), test.mapping)
+			record, line, err := reader.next()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line != 2 {
+				t.Errorf("line = %d, want 2", line)
+			}
+
+			var request web.TaskCreateRequest
+			rowErrors := reader.parse(record, &request, assigneeByEmail)
+			if len(rowErrors) != test.wantErrors {
+				t.Errorf("row errors = %v, want %d", rowErrors, test.wantErrors)
+			}
+			if request.Title != test.want.Title || request.Status != test.want.Status || request.Effort != test.want.Effort {
+				t.Errorf("request = %+v, want %+v", request, test.want)
+			}
+			if strings.Join(request.Labels, "|") != strings.Join(test.want.Labels, "|") {
+				t.Errorf("labels = %v, want %v", request.Labels, test.want.Labels)
+			}
+			if (request.AssigneeId == nil) != (test.want.AssigneeId == nil) ||
+				(request.AssigneeId != nil && *request.AssigneeId != *test.want.AssigneeId) {
+				t.Errorf("assignee = %v, want %v", request.AssigneeId, test.want.AssigneeId)
+			}
+			if (request.DueDate == nil) != (test.want.DueDate == nil) ||
+				(request.DueDate != nil && !request.DueDate.Equal(*test.want.DueDate)) {
+				t.Errorf("due date = %v, want %v", request.DueDate, test.want.DueDate)
+			}
+		})
+	}
+}
+
+func timePtr(value time.Time) *time.Time {
+	return &value
+}
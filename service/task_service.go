@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"task-management/model/web"
 
 	"github.com/google/uuid"
@@ -14,4 +15,12 @@ type TaskService interface {
 	FindById(ctx context.Context, taskId uuid.UUID) web.TaskResponse
 	FindByProjectId(ctx context.Context, projectId uuid.UUID) []web.TaskResponse
 	FindAll(ctx context.Context) []web.TaskResponse
+	Bulk(ctx context.Context, request web.TaskBulkRequest) web.TaskBulkResponse
+	// Import membuat task dari file CSV dengan laporan error per baris
+	Import(ctx context.Context, request web.TaskImportRequest, file io.Reader) web.TaskImportResponse
+	// Watch dan Unwatch mengatur apakah user yang sedang login mengikuti notifikasi task
+	Watch(ctx context.Context, taskId uuid.UUID)
+	Unwatch(ctx context.Context, taskId uuid.UUID)
+	// FindWatching mengambil task yang diikuti user yang sedang login
+	FindWatching(ctx context.Context) []web.TaskResponse
 }
\ No newline at end of file
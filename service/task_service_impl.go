@@ -2,8 +2,14 @@ package service
 
 import (
 	"context"
-	"time"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
+	"task-management/event"
+	"task-management/exception"
 	"task-management/helper"
 	"task-management/model/domain"
 	"task-management/model/web"
@@ -14,14 +20,26 @@ import (
 )
 
 type TaskServiceImpl struct {
-	TaskRepository repository.TaskRepository
-	Validator      *validator.Validate
+	TaskRepository          repository.TaskRepository
+	ProjectRepository       repository.ProjectRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	HistoryRepository       repository.TaskStatusHistoryRepository
+	WatcherRepository       repository.TaskWatcherRepository
+	EventBus                *event.Bus
+	DB                      *sql.DB
+	Validator               *validator.Validate
 }
 
-func NewTaskService(taskRepository repository.TaskRepository, validator *validator.Validate) TaskService {
+func NewTaskService(taskRepository repository.TaskRepository, projectRepository repository.ProjectRepository, projectMemberRepository repository.ProjectMemberRepository, historyRepository repository.TaskStatusHistoryRepository, watcherRepository repository.TaskWatcherRepository, eventBus *event.Bus, db *sql.DB, validator *validator.Validate) TaskService {
 	return &TaskServiceImpl{
-		TaskRepository: taskRepository,
-		Validator:      validator,
+		TaskRepository:          taskRepository,
+		ProjectRepository:       projectRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		HistoryRepository:       historyRepository,
+		WatcherRepository:       watcherRepository,
+		EventBus:                eventBus,
+		DB:                      db,
+		Validator:               validator,
 	}
 }
 
@@ -30,6 +48,23 @@ func (service *TaskServiceImpl) Create(ctx context.Context, request web.TaskCrea
 	err := service.Validator.Struct(request)
 	helper.PanicIfError(err)
 
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	ensureProjectWritable(service.authorizeTaskProject(ctx, tx, request.ProjectId, domain.ProjectRoleMember))
+
+	result, err := service.createTask(ctx, tx, request)
+	helper.PanicIfError(err)
+
+	recalculateProjectProgress(ctx, tx, service.ProjectRepository, service.TaskRepository, result.ProjectId)
+
+	return helper.ToTaskResponse(result)
+}
+
+// createTask menyimpan task baru beserta riwayat, watcher dan event-nya.
+// Request harus sudah divalidasi dan akses ke project sudah diperiksa.
+func (service *TaskServiceImpl) createTask(ctx context.Context, tx *sql.Tx, request web.TaskCreateRequest) (domain.Task, error) {
 	// Generate UUID baru biar gak duplicate
 	newID := uuid.New()
 
@@ -43,22 +78,46 @@ func (service *TaskServiceImpl) Create(ctx context.Context, request web.TaskCrea
 		DifficultyLevel: request.DifficultyLevel,
 		Deliverable:     request.Deliverable,
 		Bottleneck:      request.Bottleneck,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		AssigneeId:      request.AssigneeId,
+		Labels:          request.Labels,
+		DueDate:         request.DueDate,
+		CreatedAt:       helper.Now(),
+		UpdatedAt:       helper.Now(),
 	}
 
-	result, err := service.TaskRepository.Save(ctx, task)
-	helper.PanicIfError(err)
+	return saveNewTask(ctx, tx, service.TaskRepository, service.HistoryRepository, service.WatcherRepository, service.EventBus, task)
+}
 
-	return helper.ToTaskResponse(result)
+// saveNewTask adalah jalur penyimpanan task baru untuk semua fitur (create,
+// import, clone project): riwayat status, watcher otomatis dan event task dibuat
+// selalu ikut tercatat.
+func saveNewTask(ctx context.Context, tx *sql.Tx, taskRepository repository.TaskRepository, historyRepository repository.TaskStatusHistoryRepository, watcherRepository repository.TaskWatcherRepository, eventBus *event.Bus, task domain.Task) (domain.Task, error) {
+	result, err := taskRepository.Save(ctx, tx, task)
+	if err != nil {
+		return result, err
+	}
+	recordTaskChange(ctx, tx, historyRepository, nil, &result)
+	autoWatchTask(ctx, tx, watcherRepository, nil, result)
+	publishTaskChange(ctx, tx, eventBus, nil, &result)
+	return result, nil
 }
 
 func (service *TaskServiceImpl) Update(ctx context.Context, taskId uuid.UUID, request web.TaskUpdateRequest) web.TaskResponse {
 	err := service.Validator.Struct(request)
 	helper.PanicIfError(err)
 
-	task, err := service.TaskRepository.FindById(ctx, taskId)
+	tx, err := helper.BeginTx(ctx, service.DB)
 	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	task, err := service.TaskRepository.FindById(ctx, tx, taskId)
+	helper.PanicIfError(err)
+
+	ensureProjectWritable(service.authorizeTaskProject(ctx, tx, task.ProjectId, domain.ProjectRoleMember))
+	if request.ExpectedUpdatedAt != nil && !request.ExpectedUpdatedAt.Equal(task.UpdatedAt) {
+		panic(exception.NewConflictError("task has been modified since it was loaded, reload and try again"))
+	}
+	before := task
 
 	// Only update fields that are provided (non-nil)
 	if request.Title != nil {
@@ -88,36 +147,416 @@ func (service *TaskServiceImpl) Update(ctx context.Context, taskId uuid.UUID, re
 	if request.Progress != nil {
 		task.Progress = *request.Progress
 	}
-	task.UpdatedAt = time.Now()
+	if request.AssigneeId != nil {
+		task.AssigneeId = request.AssigneeId
+	}
+	if request.Labels != nil {
+		task.Labels = *request.Labels
+	}
+	if request.DueDate != nil {
+		task.DueDate = request.DueDate
+	}
+	task.UpdatedAt = helper.Now()
 
-	result, err := service.TaskRepository.Update(ctx, task)
+	result, err := service.TaskRepository.Update(ctx, tx, task)
 	helper.PanicIfError(err)
+	recordTaskChange(ctx, tx, service.HistoryRepository, &before, &result)
+	autoWatchTask(ctx, tx, service.WatcherRepository, &before, result)
+	publishTaskChange(ctx, tx, service.EventBus, &before, &result)
+
+	recalculateProjectProgress(ctx, tx, service.ProjectRepository, service.TaskRepository, result.ProjectId)
 
 	return helper.ToTaskResponse(result)
 }
 
 func (service *TaskServiceImpl) Delete(ctx context.Context, taskId uuid.UUID) {
-	err := service.TaskRepository.Delete(ctx, taskId)
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	task, err := service.TaskRepository.FindById(ctx, tx, taskId)
+	helper.PanicIfError(err)
+
+	ensureProjectWritable(service.authorizeTaskProject(ctx, tx, task.ProjectId, domain.ProjectRoleMaintainer))
+
+	err = service.TaskRepository.Delete(ctx, tx, taskId)
 	helper.PanicIfError(err)
+	recordTaskChange(ctx, tx, service.HistoryRepository, &task, nil)
+	publishTaskChange(ctx, tx, service.EventBus, &task, nil)
+
+	recalculateProjectProgress(ctx, tx, service.ProjectRepository, service.TaskRepository, task.ProjectId)
 }
 
 func (service *TaskServiceImpl) FindById(ctx context.Context, taskId uuid.UUID) web.TaskResponse {
-	task, err := service.TaskRepository.FindById(ctx, taskId)
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	task, err := service.TaskRepository.FindById(ctx, tx, taskId)
 	helper.PanicIfError(err)
 
+	service.authorizeTaskProject(ctx, tx, task.ProjectId, domain.ProjectRoleViewer)
+
 	return helper.ToTaskResponse(task)
 }
 
 func (service *TaskServiceImpl) FindByProjectId(ctx context.Context, projectId uuid.UUID) []web.TaskResponse {
-	tasks, err := service.TaskRepository.FindByProjectId(ctx, projectId)
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.authorizeTaskProject(ctx, tx, projectId, domain.ProjectRoleViewer)
+
+	tasks, err := service.TaskRepository.FindByProjectId(ctx, tx, projectId)
 	helper.PanicIfError(err)
 
 	return helper.ToTaskResponses(tasks)
 }
 
+// FindAll hanya mengembalikan task dari project yang bisa diakses user yang sedang login
 func (service *TaskServiceImpl) FindAll(ctx context.Context) []web.TaskResponse {
-	tasks, err := service.TaskRepository.FindAll(ctx)
+	tx, err := helper.BeginTx(ctx, service.DB)
 	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
 
-	return helper.ToTaskResponses(tasks)
+	userId, _ := helper.UserIdFromContext(ctx)
+	accessible := map[uuid.UUID]bool{}
+	for _, project := range service.ProjectRepository.FindByUserId(ctx, tx, userId) {
+		accessible[project.Id] = true
+	}
+
+	tasks, err := service.TaskRepository.FindAll(ctx, tx)
+	helper.PanicIfError(err)
+
+	var visibleTasks []domain.Task
+	for _, task := range tasks {
+		if accessible[task.ProjectId] {
+			visibleTasks = append(visibleTasks, task)
+		}
+	}
+
+	return helper.ToTaskResponses(visibleTasks)
+}
+
+// Bulk menerapkan update atau delete ke banyak task dalam satu transaksi.
+// Setiap task dijalankan di savepoint sendiri sehingga kegagalan satu task
+// tidak membatalkan task lain, kecuali AllOrNothing aktif.
+func (service *TaskServiceImpl) Bulk(ctx context.Context, request web.TaskBulkRequest) web.TaskBulkResponse {
+	err := service.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	taskIds := request.TaskIds
+	if len(taskIds) == 0 {
+		taskIds = service.findBulkTaskIds(ctx, tx, request.Filter)
+	}
+
+	// Project tujuan harus ada dan bisa diubah user sebelum task dipindahkan
+	if request.Action == "update" && request.Patch.ProjectId != nil {
+		ensureProjectWritable(service.authorizeTaskProject(ctx, tx, *request.Patch.ProjectId, domain.ProjectRoleMember))
+	}
+
+	// Role minimal di project asal task, sama seperti Update dan Delete biasa
+	minRole := domain.ProjectRoleMember
+	if request.Action == "delete" {
+		minRole = domain.ProjectRoleMaintainer
+	}
+
+	response := web.TaskBulkResponse{
+		Action:       request.Action,
+		AllOrNothing: request.AllOrNothing,
+		Total:        len(taskIds),
+		Committed:    true,
+		Results:      make([]web.TaskBulkItemResult, 0, len(taskIds)),
+	}
+
+	_, err = tx.ExecContext(ctx, "SAVEPOINT bulk_start")
+	helper.PanicIfError(err)
+
+	// Project yang task-nya berubah, progress-nya dihitung ulang di akhir
+	affectedProjects := map[uuid.UUID]bool{}
+
+	for _, taskId := range taskIds {
+		err := helper.WithSavepoint(ctx, tx, "bulk_item", func() error {
+			task, err := service.TaskRepository.FindById(ctx, tx, taskId)
+			if err != nil {
+				return err
+			}
+			project, err := service.ProjectRepository.FindById(ctx, tx, task.ProjectId)
+			if err != nil {
+				return err
+			}
+			if !canAccessProject(ctx, tx, service.ProjectMemberRepository, project, minRole) {
+				return errors.New("forbidden: requires " + minRole + " role in project " + project.Id.String())
+			}
+			if project.Status == domain.ProjectStatusArchived {
+				return errors.New("project " + project.Id.String() + " is archived and read-only")
+			}
+			affectedProjects[task.ProjectId] = true
+
+			if request.Action == "delete" {
+				if err := service.TaskRepository.Delete(ctx, tx, taskId); err != nil {
+					return err
+				}
+				recordTaskChange(ctx, tx, service.HistoryRepository, &task, nil)
+				publishTaskChange(ctx, tx, service.EventBus, &task, nil)
+				return nil
+			}
+			return service.applyBulkPatch(ctx, tx, task, *request.Patch)
+		})
+
+		result := web.TaskBulkItemResult{TaskId: taskId, Success: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	// Mode all-or-nothing: batalkan semua perubahan jika ada satu saja yang gagal
+	if request.AllOrNothing && response.Failed > 0 {
+		_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_start")
+		helper.PanicIfError(err)
+		response.Committed = false
+		return response
+	}
+
+	if request.Action == "update" && request.Patch.ProjectId != nil {
+		affectedProjects[*request.Patch.ProjectId] = true
+	}
+	for projectId := range affectedProjects {
+		recalculateProjectProgress(ctx, tx, service.ProjectRepository, service.TaskRepository, projectId)
+	}
+
+	return response
+}
+
+// Watch membuat user mengikuti task; cukup role viewer di project task
+func (service *TaskServiceImpl) Watch(ctx context.Context, taskId uuid.UUID) {
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	task, err := service.TaskRepository.FindById(ctx, tx, taskId)
+	helper.PanicIfError(err)
+
+	service.authorizeTaskProject(ctx, tx, task.ProjectId, domain.ProjectRoleViewer)
+
+	userId, _ := helper.UserIdFromContext(ctx)
+	service.WatcherRepository.Save(ctx, tx, domain.TaskWatcher{TaskId: task.Id, UserId: userId})
+}
+
+// Unwatch berhenti mengikuti task; tidak perlu akses project agar user tetap bisa berhenti
+// mengikuti task dari project yang aksesnya sudah dicabut
+func (service *TaskServiceImpl) Unwatch(ctx context.Context, taskId uuid.UUID) {
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	task, err := service.TaskRepository.FindById(ctx, tx, taskId)
+	helper.PanicIfError(err)
+
+	userId, _ := helper.UserIdFromContext(ctx)
+	service.WatcherRepository.Delete(ctx, tx, task.Id, userId)
+}
+
+// FindWatching hanya mengembalikan task yang diikuti dan project-nya masih bisa diakses user
+func (service *TaskServiceImpl) FindWatching(ctx context.Context) []web.TaskResponse {
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	userId, _ := helper.UserIdFromContext(ctx)
+	accessible := map[uuid.UUID]bool{}
+	for _, project := range service.ProjectRepository.FindByUserId(ctx, tx, userId) {
+		accessible[project.Id] = true
+	}
+
+	tasks, err := service.TaskRepository.FindWatchedByUserId(ctx, tx, userId)
+	helper.PanicIfError(err)
+
+	visibleTasks := []domain.Task{}
+	for _, task := range tasks {
+		if accessible[task.ProjectId] {
+			visibleTasks = append(visibleTasks, task)
+		}
+	}
+
+	return helper.ToTaskResponses(visibleTasks)
+}
+
+// Import membuat task dari CSV. Setiap baris divalidasi seperti Create dan
+// disimpan di savepoint sendiri, sehingga baris yang gagal tidak membatalkan
+// baris lain. Dry run dan all-or-nothing yang gagal membatalkan semuanya di akhir.
+func (service *TaskServiceImpl) Import(ctx context.Context, request web.TaskImportRequest, file io.Reader) web.TaskImportResponse {
+	importReader := newTaskImportReader(file, request.Mapping)
+
+	tx, err := helper.BeginTx(ctx, service.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project := service.authorizeTaskProject(ctx, tx, request.ProjectId, domain.ProjectRoleMember)
+	ensureProjectWritable(project)
+
+	// Assignee boleh ditulis sebagai email anggota project
+	var membersByEmail map[string]uuid.UUID
+	assigneeByEmail := func(email string) (uuid.UUID, bool) {
+		if membersByEmail == nil {
+			membersByEmail = map[string]uuid.UUID{}
+			for _, member := range service.ProjectMemberRepository.FindByProjectId(ctx, tx, project.Id) {
+				membersByEmail[strings.ToLower(member.Email)] = member.UserId
+			}
+		}
+		userId, found := membersByEmail[strings.ToLower(email)]
+		return userId, found
+	}
+
+	response := web.TaskImportResponse{
+		DryRun:       request.DryRun,
+		AllOrNothing: request.AllOrNothing,
+		Committed:    true,
+		Results:      []web.TaskImportRowResult{},
+	}
+
+	_, err = tx.ExecContext(ctx, "SAVEPOINT import_start")
+	helper.PanicIfError(err)
+
+	for {
+		record, line, err := importReader.next()
+		if err == io.EOF {
+			break
+		}
+		if response.Total == taskImportMaxRows {
+			panic(exception.NewBadRequestError(fmt.Sprintf("CSV has more than %d rows, split it into smaller files", taskImportMaxRows)))
+		}
+		response.Total++
+
+		result := web.TaskImportRowResult{Row: line}
+		if err != nil {
+			result.Errors = []string{err.Error()}
+		} else {
+			createRequest := web.TaskCreateRequest{ProjectId: project.Id}
+			result.Errors = importReader.parse(record, &createRequest, assigneeByEmail)
+			if err := service.Validator.Struct(createRequest); err != nil {
+				result.Errors = appendFieldErrors(result.Errors, validationMessages(err, createRequest))
+			}
+			if len(result.Errors) == 0 {
+				err := helper.WithSavepoint(ctx, tx, "import_row", func() error {
+					task, err := service.createTask(ctx, tx, createRequest)
+					if err == nil {
+						result.TaskId = &task.Id
+					}
+					return err
+				})
+				if err != nil {
+					result.Errors = []string{err.Error()}
+				}
+			}
+		}
+
+		result.Success = len(result.Errors) == 0
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	if request.DryRun || (request.AllOrNothing && response.Failed > 0) {
+		_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_start")
+		helper.PanicIfError(err)
+		response.Committed = false
+		// Task yang dibatalkan tidak punya ID
+		for i := range response.Results {
+			response.Results[i].TaskId = nil
+		}
+		return response
+	}
+
+	if response.Succeeded > 0 {
+		recalculateProjectProgress(ctx, tx, service.ProjectRepository, service.TaskRepository, project.Id)
+	}
+	return response
+}
+
+func (service *TaskServiceImpl) findBulkTaskIds(ctx context.Context, tx *sql.Tx, filter *web.TaskBulkFilter) []uuid.UUID {
+	if filter.ProjectId == nil && filter.Status == nil && filter.Priority == nil &&
+		filter.AssigneeId == nil && filter.Label == nil {
+		panic(exception.NewBadRequestError("bulk filter must contain at least one criterion"))
+	}
+
+	tasks, err := service.TaskRepository.FindByFilter(ctx, tx, domain.TaskFilter{
+		ProjectId:  filter.ProjectId,
+		Status:     filter.Status,
+		Priority:   filter.Priority,
+		AssigneeId: filter.AssigneeId,
+		Label:      filter.Label,
+	})
+	helper.PanicIfError(err)
+
+	taskIds := make([]uuid.UUID, 0, len(tasks))
+	for _, task := range tasks {
+		taskIds = append(taskIds, task.Id)
+	}
+	return taskIds
+}
+
+func (service *TaskServiceImpl) applyBulkPatch(ctx context.Context, tx *sql.Tx, task domain.Task, patch web.TaskBulkPatch) error {
+	before := task
+	if patch.Status != nil {
+		task.Status = *patch.Status
+	}
+	if patch.Priority != nil {
+		task.Priority = *patch.Priority
+	}
+	if patch.Labels != nil {
+		task.Labels = *patch.Labels
+	}
+	if patch.AssigneeId != nil {
+		task.AssigneeId = patch.AssigneeId
+	}
+	if patch.UnassignAssignee {
+		task.AssigneeId = nil
+	}
+	if patch.ProjectId != nil {
+		task.ProjectId = *patch.ProjectId
+	}
+
+	result, err := service.TaskRepository.Update(ctx, tx, task)
+	if err != nil {
+		return err
+	}
+	recordTaskChange(ctx, tx, service.HistoryRepository, &before, &result)
+	autoWatchTask(ctx, tx, service.WatcherRepository, &before, result)
+	publishTaskChange(ctx, tx, service.EventBus, &before, &result)
+	return nil
+}
+
+// autoWatchTask menjadikan pembuat dan assignee task sebagai watcher saat task
+// dibuat, dan assignee baru saat task ditugaskan ulang
+func autoWatchTask(ctx context.Context, tx *sql.Tx, watcherRepository repository.TaskWatcherRepository, before *domain.Task, after domain.Task) {
+	if before == nil {
+		if userId, ok := helper.UserIdFromContext(ctx); ok {
+			watcherRepository.Save(ctx, tx, domain.TaskWatcher{TaskId: after.Id, UserId: userId})
+		}
+	}
+	if after.AssigneeId != nil && (before == nil || before.AssigneeId == nil || *before.AssigneeId != *after.AssigneeId) {
+		watcherRepository.Save(ctx, tx, domain.TaskWatcher{TaskId: after.Id, UserId: *after.AssigneeId})
+	}
+}
+
+// authorizeTaskProject memastikan project ada dan user yang sedang login
+// punya minimal minRole di project tersebut.
+func (service *TaskServiceImpl) authorizeTaskProject(ctx context.Context, tx *sql.Tx, projectId uuid.UUID, minRole string) domain.Project {
+	project, err := service.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	authorizeProject(ctx, tx, service.ProjectMemberRepository, project, minRole)
+	return project
 }
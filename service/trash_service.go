@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/model/web"
+)
+
+// TrashService mengelola project dan task yang sudah di-soft delete.
+type TrashService interface {
+	// FindAll mengambil isi trash yang boleh di-restore oleh user yang sedang login.
+	FindAll(ctx context.Context) []web.TrashItemResponse
+
+	// RestoreProject mengembalikan project dari trash (khusus owner).
+	RestoreProject(ctx context.Context, projectId uuid.UUID) web.ProjectResponse
+
+	// RestoreTask mengembalikan task dari trash ke project-nya.
+	RestoreTask(ctx context.Context, taskId uuid.UUID) web.TaskResponse
+
+	// Purge menghapus permanen item yang melewati masa retensi trash.
+	Purge(ctx context.Context)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+type TrashServiceImpl struct {
+	ProjectRepository       repository.ProjectRepository
+	TaskRepository          repository.TaskRepository
+	ProjectMemberRepository repository.ProjectMemberRepository
+	HistoryRepository       repository.TaskStatusHistoryRepository
+	EventBus                *event.Bus
+	DB                      *sql.DB
+	// Retention adalah lama item disimpan di trash sebelum dihapus permanen
+	Retention time.Duration
+}
+
+func NewTrashService(
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	historyRepository repository.TaskStatusHistoryRepository,
+	eventBus *event.Bus,
+	db *sql.DB,
+	retention time.Duration,
+) TrashService {
+	return &TrashServiceImpl{
+		ProjectRepository:       projectRepository,
+		TaskRepository:          taskRepository,
+		ProjectMemberRepository: projectMemberRepository,
+		HistoryRepository:       historyRepository,
+		EventBus:                eventBus,
+		DB:                      db,
+		Retention:               retention,
+	}
+}
+
+func (s *TrashServiceImpl) FindAll(ctx context.Context) []web.TrashItemResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	items := []web.TrashItemResponse{}
+
+	// Role minimal sama dengan role yang dibutuhkan untuk menghapus
+	for _, project := range s.ProjectRepository.FindDeleted(ctx, tx) {
+		if !canAccessProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleOwner) {
+			continue
+		}
+		items = append(items, s.toTrashItem("project", project.Id, project.Name, project.Id, *project.DeletedAt))
+	}
+
+	tasks, err := s.TaskRepository.FindDeleted(ctx, tx)
+	helper.PanicIfError(err)
+	projects := map[uuid.UUID]domain.Project{}
+	for _, task := range tasks {
+		project, ok := projects[task.ProjectId]
+		if !ok {
+			project, err = s.ProjectRepository.FindById(ctx, tx, task.ProjectId)
+			helper.PanicIfError(err)
+			projects[task.ProjectId] = project
+		}
+		if !canAccessProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer) {
+			continue
+		}
+		items = append(items, s.toTrashItem("task", task.Id, task.Title, task.ProjectId, *task.DeletedAt))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items
+}
+
+func (s *TrashServiceImpl) RestoreProject(ctx context.Context, projectId uuid.UUID) web.ProjectResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	project, err := s.ProjectRepository.FindDeletedById(ctx, tx, projectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleOwner)
+
+	err = s.ProjectRepository.Restore(ctx, tx, projectId)
+	helper.PanicIfError(err)
+
+	project, err = s.ProjectRepository.FindById(ctx, tx, projectId)
+	helper.PanicIfError(err)
+	publishProjectChange(ctx, tx, s.EventBus, nil, &project)
+
+	return toProjectResponse(project)
+}
+
+func (s *TrashServiceImpl) RestoreTask(ctx context.Context, taskId uuid.UUID) web.TaskResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	task, err := s.TaskRepository.FindDeletedById(ctx, tx, taskId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	project, err := s.ProjectRepository.FindById(ctx, tx, task.ProjectId)
+	if err != nil {
+		panic(exception.NewConflictError("project of this task is in the trash, restore the project first"))
+	}
+
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+	ensureProjectWritable(project)
+
+	err = s.TaskRepository.Restore(ctx, tx, taskId)
+	helper.PanicIfError(err)
+
+	task, err = s.TaskRepository.FindById(ctx, tx, taskId)
+	helper.PanicIfError(err)
+	recordTaskChange(ctx, tx, s.HistoryRepository, nil, &task)
+	publishTaskChange(ctx, tx, s.EventBus, nil, &task)
+
+	recalculateProjectProgress(ctx, tx, s.ProjectRepository, s.TaskRepository, task.ProjectId)
+
+	return helper.ToTaskResponse(task)
+}
+
+func (s *TrashServiceImpl) Purge(ctx context.Context) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	before := helper.Now().Add(-s.Retention)
+
+	tasks, err := s.TaskRepository.Purge(ctx, tx, before)
+	helper.PanicIfError(err)
+	projects := s.ProjectRepository.Purge(ctx, tx, before)
+
+	if tasks > 0 || projects > 0 {
+		fmt.Printf("🗑️ Purged %d project(s) and %d task(s) from trash\n", projects, tasks)
+	}
+}
+
+func (s *TrashServiceImpl) toTrashItem(itemType string, id uuid.UUID, name string, projectId uuid.UUID, deletedAt time.Time) web.TrashItemResponse {
+	return web.TrashItemResponse{
+		Type:      itemType,
+		Id:        id,
+		Name:      name,
+		ProjectId: projectId,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(s.Retention),
+	}
+}
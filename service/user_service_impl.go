@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"task-management/event"
 	"task-management/helper"
 	"task-management/model/domain"
 	"task-management/model/web"
@@ -21,6 +22,8 @@ type UserServiceImpl struct {
 	UserRepository         repository.UserRepository
 	ProfileRepository      repository.ProfileRepository
 	RefreshTokenRepository repository.RefreshTokenRepository
+	WorkspaceRepository    repository.WorkspaceRepository
+	EventBus               *event.Bus
 	DB                     *sql.DB
 	JwtSecret              []byte
 }
@@ -30,6 +33,8 @@ func NewUserService(
 	userRepository repository.UserRepository,
 	profileRepository repository.ProfileRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	workspaceRepository repository.WorkspaceRepository,
+	eventBus *event.Bus,
 	db *sql.DB,
 	jwtSecret []byte,
 ) UserService {
@@ -40,19 +45,36 @@ func NewUserService(
 		UserRepository:         userRepository,
 		ProfileRepository:      profileRepository,
 		RefreshTokenRepository: refreshTokenRepo,
+		WorkspaceRepository:    workspaceRepository,
+		EventBus:               eventBus,
 		DB:                     db,
 		JwtSecret:              jwtSecret,
 	}
 }
 
-// Register user baru
+// Register user baru. Pendaftaran publik membuat workspace baru; jika dipanggil
+// oleh user yang sedang login, user baru masuk ke workspace pemanggil.
 func (s *UserServiceImpl) Register(ctx context.Context, request web.UserRegisterRequest) (web.UserResponse, error) {
-	tx, err := s.DB.Begin()
+	workspaceId, joinExisting := helper.WorkspaceIdFromContext(ctx)
+	if !joinExisting {
+		workspaceId = uuid.New()
+		ctx = helper.ContextWithWorkspaceId(ctx, workspaceId)
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
 	if err != nil {
 		return web.UserResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if !joinExisting {
+		workspaceName := strings.TrimSpace(request.WorkspaceName)
+		if workspaceName == "" {
+			workspaceName = strings.TrimSpace(request.FullName) + "'s workspace"
+		}
+		s.WorkspaceRepository.Save(ctx, tx, domain.Workspace{Id: workspaceId, Name: workspaceName})
+	}
+
 	email := strings.ToLower(strings.TrimSpace(request.Email))
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
@@ -66,29 +88,23 @@ func (s *UserServiceImpl) Register(ctx context.Context, request web.UserRegister
 		Email:        email,
 		PasswordHash: string(hashedPassword),
 		Role:         request.Role,
+		WorkspaceId:  workspaceId,
 	}
 
 	savedUser := s.UserRepository.Save(ctx, tx, user)
 
-	// Buat profile untuk user baru
-	profile := domain.Profile{
-		UserId:   savedUser.Id,
-		FullName: savedUser.FullName,
-		Email:    savedUser.Email,
-		Role:     savedUser.Role,
-	}
-
-	// Simpan profile
-	s.ProfileRepository.Save(ctx, tx, profile)
+	// Profile dibuat oleh subscriber event di transaksi yang sama
+	s.EventBus.Publish(ctx, tx, domain.UserRegistered{User: savedUser})
 
 	return web.UserResponse{
-		Id:        savedUser.Id,
-		FullName:  savedUser.FullName,
-		Email:     savedUser.Email,
-		Role:      savedUser.Role,
-		CreatedAt: savedUser.CreatedAt,
-		UpdatedAt: savedUser.UpdatedAt,
-		DeletedAt: savedUser.DeletedAt,
+		Id:          savedUser.Id,
+		FullName:    savedUser.FullName,
+		Email:       savedUser.Email,
+		Role:        savedUser.Role,
+		WorkspaceId: savedUser.WorkspaceId,
+		CreatedAt:   savedUser.CreatedAt,
+		UpdatedAt:   savedUser.UpdatedAt,
+		DeletedAt:   savedUser.DeletedAt,
 	}, nil
 }
 
@@ -96,7 +112,10 @@ func (s *UserServiceImpl) Register(ctx context.Context, request web.UserRegister
 func (s *UserServiceImpl) Login(ctx context.Context, request web.UserLoginRequest) (accessToken string, refreshToken string, err error) {
 	email := strings.ToLower(strings.TrimSpace(request.Email))
 
-	user, err := s.UserRepository.FindByEmail(ctx, nil, email)
+	// Workspace user belum diketahui sebelum login, jadi pencarian lintas workspace
+	user, timezone, err := s.findTokenUser(ctx, func(tx *sql.Tx) (domain.User, error) {
+		return s.UserRepository.FindByEmail(ctx, tx, email)
+	})
 	if err != nil {
 		return "", "", err
 	}
@@ -111,11 +130,13 @@ func (s *UserServiceImpl) Login(ctx context.Context, request web.UserLoginReques
 
 	// Generate access token (1 jam)
 	accessClaims := jwt.MapClaims{
-		"user_id":   user.Id.String(),
-		"full_name": user.FullName,
-		"email":     user.Email,
-		"role":      user.Role,
-		"exp":       time.Now().Add(time.Hour * 1).Unix(),
+		"user_id":      user.Id.String(),
+		"full_name":    user.FullName,
+		"email":        user.Email,
+		"role":         user.Role,
+		"workspace_id": user.WorkspaceId.String(),
+		"timezone":     timezone,
+		"exp":          time.Now().Add(time.Hour * 1).Unix(),
 	}
 	accessJWT := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
 	accessToken, err = accessJWT.SignedString(s.JwtSecret)
@@ -145,23 +166,28 @@ func (s *UserServiceImpl) Login(ctx context.Context, request web.UserLoginReques
 
 // Refresh → generate access + refresh token baru
 func (s *UserServiceImpl) Refresh(ctx context.Context, oldRefreshToken string) (newAccess string, newRefresh string, err error) {
+	// Refresh token disimpan di memori, bukan di tabel, jadi tidak perlu transaksi (dan tidak terkena RLS)
 	tokenData, err := s.RefreshTokenRepository.FindByToken(ctx, nil, oldRefreshToken)
 	if err != nil {
 		return "", "", err
 	}
 
-	user, err := s.UserRepository.FindById(ctx, nil, tokenData.UserID)
+	user, timezone, err := s.findTokenUser(ctx, func(tx *sql.Tx) (domain.User, error) {
+		return s.UserRepository.FindById(ctx, tx, tokenData.UserID)
+	})
 	if err != nil {
 		return "", "", err
 	}
 
 	// Generate access token baru
 	accessClaims := jwt.MapClaims{
-		"user_id":   user.Id.String(),
-		"full_name": user.FullName,
-		"email":     user.Email,
-		"role":      user.Role,
-		"exp":       time.Now().Add(time.Hour * 1).Unix(),
+		"user_id":      user.Id.String(),
+		"full_name":    user.FullName,
+		"email":        user.Email,
+		"role":         user.Role,
+		"workspace_id": user.WorkspaceId.String(),
+		"timezone":     timezone,
+		"exp":          time.Now().Add(time.Hour * 1).Unix(),
 	}
 	accessJWT := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
 	newAccess, err = accessJWT.SignedString(s.JwtSecret)
@@ -194,7 +220,7 @@ func (s *UserServiceImpl) Logout(ctx context.Context, refreshToken string) error
 
 // Update user
 func (s *UserServiceImpl) Update(ctx context.Context, request web.UserUpdateRequest) (web.UserResponse, error) {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	if err != nil {
 		return web.UserResponse{}, err
 	}
@@ -226,21 +252,23 @@ func (s *UserServiceImpl) Update(ctx context.Context, request web.UserUpdateRequ
 	}
 
 	updatedUser := s.UserRepository.Update(ctx, tx, existingUser)
+	s.EventBus.Publish(ctx, tx, domain.UserUpdated{User: updatedUser})
 
 	return web.UserResponse{
-		Id:        updatedUser.Id,
-		FullName:  updatedUser.FullName,
-		Email:     updatedUser.Email,
-		Role:      updatedUser.Role,
-		CreatedAt: updatedUser.CreatedAt,
-		UpdatedAt: updatedUser.UpdatedAt,
-		DeletedAt: updatedUser.DeletedAt,
+		Id:          updatedUser.Id,
+		FullName:    updatedUser.FullName,
+		Email:       updatedUser.Email,
+		Role:        updatedUser.Role,
+		WorkspaceId: updatedUser.WorkspaceId,
+		CreatedAt:   updatedUser.CreatedAt,
+		UpdatedAt:   updatedUser.UpdatedAt,
+		DeletedAt:   updatedUser.DeletedAt,
 	}, nil
 }
 
 // Delete user
 func (s *UserServiceImpl) Delete(ctx context.Context, userId uuid.UUID) error {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	if err != nil {
 		return err
 	}
@@ -252,7 +280,7 @@ func (s *UserServiceImpl) Delete(ctx context.Context, userId uuid.UUID) error {
 
 // FindById user
 func (s *UserServiceImpl) FindById(ctx context.Context, userId uuid.UUID) (web.UserResponse, error) {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	if err != nil {
 		return web.UserResponse{}, err
 	}
@@ -264,19 +292,20 @@ func (s *UserServiceImpl) FindById(ctx context.Context, userId uuid.UUID) (web.U
 	}
 
 	return web.UserResponse{
-		Id:        user.Id,
-		FullName:  user.FullName,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-		DeletedAt: user.DeletedAt,
+		Id:          user.Id,
+		FullName:    user.FullName,
+		Email:       user.Email,
+		Role:        user.Role,
+		WorkspaceId: user.WorkspaceId,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		DeletedAt:   user.DeletedAt,
 	}, nil
 }
 
 // FindAll users
 func (s *UserServiceImpl) FindAll(ctx context.Context) ([]web.UserResponse, error) {
-	tx, err := s.DB.Begin()
+	tx, err := helper.BeginTx(ctx, s.DB)
 	if err != nil {
 		return nil, err
 	}
@@ -286,14 +315,32 @@ func (s *UserServiceImpl) FindAll(ctx context.Context) ([]web.UserResponse, erro
 	responses := make([]web.UserResponse, 0, len(users))
 	for _, user := range users {
 		responses = append(responses, web.UserResponse{
-			Id:        user.Id,
-			FullName:  user.FullName,
-			Email:     user.Email,
-			Role:      user.Role,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-			DeletedAt: user.DeletedAt,
+			Id:          user.Id,
+			FullName:    user.FullName,
+			Email:       user.Email,
+			Role:        user.Role,
+			WorkspaceId: user.WorkspaceId,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+			DeletedAt:   user.DeletedAt,
 		})
 	}
 	return responses, nil
 }
+
+// findTokenUser mencari user untuk diterbitkan token beserta zona waktunya di
+// dalam satu transaksi sistem (lintas workspace). Zona waktu disimpan di access
+// token, jadi perubahan timezone di profile berlaku setelah login/refresh berikutnya.
+func (s *UserServiceImpl) findTokenUser(ctx context.Context, find func(tx *sql.Tx) (domain.User, error)) (domain.User, string, error) {
+	tx, err := helper.BeginTx(helper.ContextWithSystem(ctx), s.DB)
+	if err != nil {
+		return domain.User{}, "", err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	user, err := find(tx)
+	if err != nil || user.Id == uuid.Nil {
+		return user, "", err
+	}
+	return user, profileTimezone(ctx, tx, s.ProfileRepository, user.Id), nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"task-management/event"
+	"task-management/model/web"
+)
+
+// WebhookService mengelola langganan webhook dan pengiriman event ke URL luar.
+type WebhookService interface {
+	// Create mendaftarkan webhook baru; secret hanya dikembalikan di sini.
+	Create(ctx context.Context, request web.WebhookCreateRequest) web.WebhookResponse
+
+	Update(ctx context.Context, request web.WebhookUpdateRequest) web.WebhookResponse
+
+	// Delete menghapus webhook beserta log delivery-nya.
+	Delete(ctx context.Context, webhookId uuid.UUID)
+
+	FindById(ctx context.Context, webhookId uuid.UUID) web.WebhookResponse
+
+	FindAll(ctx context.Context) []web.WebhookResponse
+
+	// FindDeliveries mengambil log delivery terbaru sebuah webhook.
+	FindDeliveries(ctx context.Context, webhookId uuid.UUID) []web.WebhookDeliveryResponse
+
+	// Redeliver mengantrekan ulang payload dari delivery sebelumnya.
+	Redeliver(ctx context.Context, webhookId uuid.UUID, deliveryId uuid.UUID) web.WebhookDeliveryResponse
+
+	// WebhookService juga sink outbox: event task/project diantrekan sebagai delivery.
+	event.Sink
+
+	// DeliverPending mengirim delivery yang sudah jatuh tempo (dijalankan job terjadwal).
+	DeliverPending(ctx context.Context)
+}
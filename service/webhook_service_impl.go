@@ -0,0 +1,401 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+const (
+	// webhookMaxAttempts adalah jumlah percobaan sebelum delivery dianggap gagal
+	webhookMaxAttempts = 10
+	// webhookBaseBackoff dikali dua setiap percobaan gagal, maksimal webhookMaxBackoff
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+	// webhookLease menahan delivery yang sedang dikirim agar tidak diambil worker lain
+	webhookLease = 5 * time.Minute
+	// webhookBatchSize adalah jumlah delivery yang diproses per putaran job
+	webhookBatchSize = 50
+	// webhookDeliveryLogLimit adalah jumlah delivery terakhir yang ditampilkan
+	webhookDeliveryLogLimit = 50
+	// webhookMaxResponseBytes membatasi body response yang disimpan di log
+	webhookMaxResponseBytes = 1024
+)
+
+type WebhookServiceImpl struct {
+	WebhookRepository         repository.WebhookRepository
+	WebhookDeliveryRepository repository.WebhookDeliveryRepository
+	ProjectRepository         repository.ProjectRepository
+	ProjectMemberRepository   repository.ProjectMemberRepository
+	DB                        *sql.DB
+	Validator                 *validator.Validate
+	Client                    *http.Client
+}
+
+func NewWebhookService(
+	webhookRepository repository.WebhookRepository,
+	webhookDeliveryRepository repository.WebhookDeliveryRepository,
+	projectRepository repository.ProjectRepository,
+	projectMemberRepository repository.ProjectMemberRepository,
+	db *sql.DB,
+	validator *validator.Validate,
+) WebhookService {
+	return &WebhookServiceImpl{
+		WebhookRepository:         webhookRepository,
+		WebhookDeliveryRepository: webhookDeliveryRepository,
+		ProjectRepository:         projectRepository,
+		ProjectMemberRepository:   projectMemberRepository,
+		DB:                        db,
+		Validator:                 validator,
+		Client:                    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *WebhookServiceImpl) Create(ctx context.Context, request web.WebhookCreateRequest) web.WebhookResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	workspaceId, ok := helper.WorkspaceIdFromContext(ctx)
+	if !ok {
+		panic(exception.NewForbiddenError("token does not belong to a workspace, please login again"))
+	}
+	userId, ok := helper.UserIdFromContext(ctx)
+	if !ok {
+		panic(exception.NewForbiddenError("user not found in context"))
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	// Webhook per project butuh role maintainer. Webhook workspace hanya dikelola
+	// pembuatnya dan hanya menerima event project yang bisa ia lihat (lihat Handle).
+	if request.ProjectId != nil {
+		s.authorizeProjectId(ctx, tx, *request.ProjectId)
+	}
+
+	webhook := s.WebhookRepository.Save(ctx, tx, domain.Webhook{
+		WorkspaceId: workspaceId,
+		ProjectId:   request.ProjectId,
+		Url:         request.Url,
+		Secret:      helper.NewWebhookSecret(),
+		Events:      request.Events,
+		Active:      true,
+		CreatedBy:   userId,
+	})
+
+	webhookResponse := toWebhookResponse(webhook)
+	webhookResponse.Secret = webhook.Secret
+	return webhookResponse
+}
+
+func (s *WebhookServiceImpl) Update(ctx context.Context, request web.WebhookUpdateRequest) web.WebhookResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	webhook := s.findWebhook(ctx, tx, request.Id)
+	webhook.Url = request.Url
+	webhook.Events = request.Events
+	if request.Active != nil {
+		webhook.Active = *request.Active
+	}
+	webhook = s.WebhookRepository.Update(ctx, tx, webhook)
+
+	return toWebhookResponse(webhook)
+}
+
+func (s *WebhookServiceImpl) Delete(ctx context.Context, webhookId uuid.UUID) {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	webhook := s.findWebhook(ctx, tx, webhookId)
+	err = s.WebhookRepository.Delete(ctx, tx, webhook.Id)
+	helper.PanicIfError(err)
+}
+
+func (s *WebhookServiceImpl) FindById(ctx context.Context, webhookId uuid.UUID) web.WebhookResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return toWebhookResponse(s.findWebhook(ctx, tx, webhookId))
+}
+
+func (s *WebhookServiceImpl) FindAll(ctx context.Context) []web.WebhookResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	webhookResponses := []web.WebhookResponse{}
+	for _, webhook := range s.WebhookRepository.FindAll(ctx, tx) {
+		if webhook.ProjectId != nil {
+			project, err := s.ProjectRepository.FindById(ctx, tx, *webhook.ProjectId)
+			if err != nil || !canAccessProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer) {
+				continue
+			}
+		} else if userId, _ := helper.UserIdFromContext(ctx); webhook.CreatedBy != userId {
+			continue
+		}
+		webhookResponses = append(webhookResponses, toWebhookResponse(webhook))
+	}
+	return webhookResponses
+}
+
+func (s *WebhookServiceImpl) FindDeliveries(ctx context.Context, webhookId uuid.UUID) []web.WebhookDeliveryResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	webhook := s.findWebhook(ctx, tx, webhookId)
+
+	deliveryResponses := []web.WebhookDeliveryResponse{}
+	for _, delivery := range s.WebhookDeliveryRepository.FindByWebhookId(ctx, tx, webhook.Id, webhookDeliveryLogLimit) {
+		deliveryResponses = append(deliveryResponses, toWebhookDeliveryResponse(delivery))
+	}
+	return deliveryResponses
+}
+
+func (s *WebhookServiceImpl) Redeliver(ctx context.Context, webhookId uuid.UUID, deliveryId uuid.UUID) web.WebhookDeliveryResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	webhook := s.findWebhook(ctx, tx, webhookId)
+	if !webhook.Active {
+		panic(exception.NewConflictError("webhook is inactive, activate it before redelivering"))
+	}
+
+	original, err := s.WebhookDeliveryRepository.FindById(ctx, tx, deliveryId)
+	if err != nil || original.WebhookId != webhook.Id {
+		panic(exception.NewNotFoundError("webhook delivery not found"))
+	}
+
+	// Payload dikirim persis sama (termasuk ID event) agar penerima bisa deduplikasi
+	delivery := s.WebhookDeliveryRepository.Save(ctx, tx, domain.WebhookDelivery{
+		WebhookId:    webhook.Id,
+		EventId:      original.EventId,
+		EventType:    original.EventType,
+		Payload:      original.Payload,
+		RedeliveryOf: &original.Id,
+	})
+	return toWebhookDeliveryResponse(delivery)
+}
+
+func (s *WebhookServiceImpl) Name() string { return "webhook" }
+
+// Handle dipanggil outbox relay: delivery untuk setiap webhook yang melanggan
+// event ini diantrekan di transaksi relay, lalu dikirim oleh DeliverPending.
+func (s *WebhookServiceImpl) Handle(ctx context.Context, tx *sql.Tx, outboxEvent domain.OutboxEvent) error {
+	// Hanya event task/project yang bisa dilanggan webhook
+	if outboxEvent.WorkspaceId == nil || !slices.Contains(domain.ProjectEventNames, outboxEvent.EventType) {
+		return nil
+	}
+	var message web.EventMessage
+	if err := json.Unmarshal([]byte(outboxEvent.Payload), &message); err != nil {
+		return err
+	}
+	if message.ProjectId == nil {
+		return nil
+	}
+
+	webhooks := s.WebhookRepository.FindSubscribed(ctx, tx, *outboxEvent.WorkspaceId, *message.ProjectId, outboxEvent.EventType)
+	project, projectErr := s.ProjectRepository.FindById(ctx, tx, *message.ProjectId)
+	if projectErr != nil {
+		// Event project.deleted datang setelah project di-soft delete
+		project, projectErr = s.ProjectRepository.FindDeletedById(ctx, tx, *message.ProjectId)
+	}
+	for _, webhook := range webhooks {
+		// Webhook workspace hanya menerima event project yang bisa dilihat pembuatnya
+		if webhook.ProjectId == nil && (projectErr != nil ||
+			!hasProjectRole(projectRole(ctx, tx, s.ProjectMemberRepository, project, webhook.CreatedBy), domain.ProjectRoleViewer)) {
+			continue
+		}
+		s.WebhookDeliveryRepository.Save(ctx, tx, domain.WebhookDelivery{
+			WebhookId: webhook.Id,
+			EventId:   outboxEvent.Id,
+			EventType: outboxEvent.EventType,
+			Payload:   outboxEvent.Payload,
+		})
+	}
+	return nil
+}
+
+func (s *WebhookServiceImpl) DeliverPending(ctx context.Context) {
+	// Klaim dan baca webhook di transaksi singkat; request HTTP dikirim di luar transaksi
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	deliveries := s.WebhookDeliveryRepository.ClaimDue(ctx, tx, helper.Now(), webhookLease, webhookBatchSize)
+	webhooks := map[uuid.UUID]*domain.Webhook{}
+	for _, delivery := range deliveries {
+		if _, ok := webhooks[delivery.WebhookId]; ok {
+			continue
+		}
+		webhook, err := s.WebhookRepository.FindById(ctx, tx, delivery.WebhookId)
+		if err != nil {
+			webhooks[delivery.WebhookId] = nil
+			continue
+		}
+		webhooks[delivery.WebhookId] = &webhook
+	}
+	helper.CommitOrRollback(tx)
+
+	for _, delivery := range deliveries {
+		s.deliver(ctx, webhooks[delivery.WebhookId], delivery)
+	}
+}
+
+// deliver mengirim satu delivery lalu menyimpan hasilnya
+func (s *WebhookServiceImpl) deliver(ctx context.Context, webhook *domain.Webhook, delivery domain.WebhookDelivery) {
+	now := helper.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	if webhook == nil || !webhook.Active {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.LastStatusCode = nil
+		delivery.LastError = "webhook is inactive"
+		delivery.LastResponse = ""
+	} else {
+		statusCode, response, err := s.send(ctx, *webhook, delivery, now)
+		delivery.LastStatusCode = statusCode
+		delivery.LastResponse = response
+		delivery.LastError = ""
+		if err == nil && *statusCode >= 200 && *statusCode < 300 {
+			delivery.Status = domain.WebhookDeliverySucceeded
+			delivery.DeliveredAt = &now
+		} else {
+			if err != nil {
+				delivery.LastError = err.Error()
+			} else {
+				delivery.LastError = "unexpected status " + strconv.Itoa(*statusCode)
+			}
+			if delivery.Attempts >= webhookMaxAttempts {
+				delivery.Status = domain.WebhookDeliveryFailed
+			} else {
+				delivery.NextAttemptAt = now.Add(helper.Backoff(webhookBaseBackoff, webhookMaxBackoff, delivery.Attempts))
+			}
+		}
+	}
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+	s.WebhookDeliveryRepository.UpdateResult(ctx, tx, delivery)
+
+	if delivery.Status == domain.WebhookDeliveryFailed {
+		fmt.Printf("⚠️ Webhook delivery %s failed after %d attempt(s): %s\n", delivery.Id, delivery.Attempts, delivery.LastError)
+	}
+}
+
+// send melakukan POST payload bertanda tangan ke URL webhook. statusCode nil
+// jika request gagal sebelum mendapat response.
+func (s *WebhookServiceImpl) send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery, now time.Time) (*int, string, error) {
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	timestamp := now.Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "task-management-webhook/1.0")
+	request.Header.Set("X-Webhook-Id", webhook.Id.String())
+	request.Header.Set("X-Webhook-Delivery", delivery.Id.String())
+	request.Header.Set("X-Webhook-Event", delivery.EventType)
+	request.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Webhook-Signature", helper.SignWebhookPayload(webhook.Secret, timestamp, body))
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, webhookMaxResponseBytes))
+	statusCode := response.StatusCode
+	return &statusCode, strings.ToValidUTF8(string(responseBody), ""), nil
+}
+
+// findWebhook memuat webhook dan memastikan user boleh mengelolanya
+func (s *WebhookServiceImpl) findWebhook(ctx context.Context, tx *sql.Tx, webhookId uuid.UUID) domain.Webhook {
+	webhook, err := s.WebhookRepository.FindById(ctx, tx, webhookId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	if webhook.ProjectId != nil {
+		s.authorizeProjectId(ctx, tx, *webhook.ProjectId)
+	} else if userId, _ := helper.UserIdFromContext(ctx); webhook.CreatedBy != userId {
+		panic(exception.NewForbiddenError("only the creator of a workspace webhook can manage it"))
+	}
+	return webhook
+}
+
+func (s *WebhookServiceImpl) authorizeProjectId(ctx context.Context, tx *sql.Tx, projectId uuid.UUID) {
+	project, err := s.ProjectRepository.FindById(ctx, tx, projectId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	authorizeProject(ctx, tx, s.ProjectMemberRepository, project, domain.ProjectRoleMaintainer)
+}
+
+func toWebhookResponse(webhook domain.Webhook) web.WebhookResponse {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+	return web.WebhookResponse{
+		Id:          webhook.Id,
+		WorkspaceId: webhook.WorkspaceId,
+		ProjectId:   webhook.ProjectId,
+		Url:         webhook.Url,
+		Events:      events,
+		Active:      webhook.Active,
+		CreatedBy:   webhook.CreatedBy,
+		CreatedAt:   webhook.CreatedAt,
+		UpdatedAt:   webhook.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery domain.WebhookDelivery) web.WebhookDeliveryResponse {
+	deliveryResponse := web.WebhookDeliveryResponse{
+		Id:             delivery.Id,
+		WebhookId:      delivery.WebhookId,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		LastResponse:   delivery.LastResponse,
+		DeliveredAt:    delivery.DeliveredAt,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	// Jadwal percobaan berikutnya hanya berarti untuk delivery yang masih pending
+	if delivery.Status == domain.WebhookDeliveryPending {
+		deliveryResponse.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return deliveryResponse
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"task-management/model/web"
+)
+
+// WorkspaceService mengelola workspace (tenant) milik user yang sedang login.
+type WorkspaceService interface {
+	// FindCurrent mengambil workspace user yang sedang login.
+	FindCurrent(ctx context.Context) web.WorkspaceResponse
+
+	// UpdateCurrent mengganti nama workspace user yang sedang login.
+	UpdateCurrent(ctx context.Context, request web.WorkspaceUpdateRequest) web.WorkspaceResponse
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"task-management/exception"
+	"task-management/helper"
+	"task-management/model/domain"
+	"task-management/model/web"
+	"task-management/repository"
+)
+
+type WorkspaceServiceImpl struct {
+	WorkspaceRepository repository.WorkspaceRepository
+	DB                  *sql.DB
+	Validator           *validator.Validate
+}
+
+func NewWorkspaceService(workspaceRepository repository.WorkspaceRepository, db *sql.DB, validator *validator.Validate) WorkspaceService {
+	return &WorkspaceServiceImpl{
+		WorkspaceRepository: workspaceRepository,
+		DB:                  db,
+		Validator:           validator,
+	}
+}
+
+func (s *WorkspaceServiceImpl) FindCurrent(ctx context.Context) web.WorkspaceResponse {
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return toWorkspaceResponse(s.findCurrent(ctx, tx))
+}
+
+func (s *WorkspaceServiceImpl) UpdateCurrent(ctx context.Context, request web.WorkspaceUpdateRequest) web.WorkspaceResponse {
+	err := s.Validator.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := helper.BeginTx(ctx, s.DB)
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	workspace := s.findCurrent(ctx, tx)
+	workspace.Name = strings.TrimSpace(request.Name)
+	workspace = s.WorkspaceRepository.Update(ctx, tx, workspace)
+
+	return toWorkspaceResponse(workspace)
+}
+
+func (s *WorkspaceServiceImpl) findCurrent(ctx context.Context, tx *sql.Tx) domain.Workspace {
+	workspaceId, ok := helper.WorkspaceIdFromContext(ctx)
+	if !ok {
+		panic(exception.NewForbiddenError("token does not belong to a workspace, please login again"))
+	}
+	workspace, err := s.WorkspaceRepository.FindById(ctx, tx, workspaceId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return workspace
+}
+
+func toWorkspaceResponse(workspace domain.Workspace) web.WorkspaceResponse {
+	return web.WorkspaceResponse{
+		Id:        workspace.Id,
+		Name:      workspace.Name,
+		CreatedAt: workspace.CreatedAt,
+		UpdatedAt: workspace.UpdatedAt,
+	}
+}